@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
@@ -13,37 +14,55 @@ import (
 	"L0/internal/config"
 	"L0/internal/database"
 	"L0/internal/handler"
+	"L0/internal/interfaces"
 	"L0/internal/kafka"
 	"L0/internal/service"
 
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/redis/go-redis/v9"
 )
 
 func main() {
 	cfg := config.LoadConfig()
 
-	db, err := database.NewDB(cfg.DBPassword, cfg.HostName)
+	db, err := database.NewDB(cfg.DBPassword, cfg.HostName, cfg.InstanceID)
 	if err != nil {
 		log.Fatal("Error connecting to database:", err)
 	}
 	defer db.Close()
 
-	orderCache := cache.NewCache()
-	orderService := service.NewOrderService(db, orderCache)
+	orderCache, err := newOrderCache(cfg)
+	if err != nil {
+		log.Fatal("Error creating cache:", err)
+	}
+	orderService, err := service.NewOrderServiceWithOptions(db, orderCache, cfg.Service)
+	if err != nil {
+		log.Fatal("Error creating order service:", err)
+	}
 
 	ctx := context.Background()
-	if err := orderService.RestoreCacheFromDB(ctx); err != nil {
+	warmUp, err := orderService.RestoreCache(ctx, cfg.WarmUp)
+	if err != nil {
 		log.Printf("Warning: failed to restore cache from DB: %v", err)
+	} else if cfg.WarmUp.Background {
+		log.Printf("Cache warm-up started in background for %d orders", warmUp.Progress().Total)
 	} else {
-		log.Printf("Cache restored successfully. Loaded %d orders", orderCache.Size())
+		progress := warmUp.Progress()
+		log.Printf("Cache restored successfully. Loaded %d orders, %d failed", progress.Loaded, progress.Failed)
 	}
 
 	cleanupCtx, cleanupCancel := context.WithCancel(ctx)
 	defer cleanupCancel()
 	go orderCache.StartCleanupWorker(cleanupCtx)
 
+	if cfg.OrderListener {
+		listener := database.NewListener(database.NewDialer(cfg.DBPassword, cfg.HostName), cfg.InstanceID,
+			orderService.ApplyOrderChange, orderService.ResyncCache)
+		go listener.Run(cleanupCtx)
+	}
+
 	if cfg.IsKafka {
-		consumer := kafka.NewConsumer(orderService, cfg.KafkaBroker)
+		consumer := kafka.NewConsumer(orderService, cfg.KafkaBroker, cfg.KafkaConflictTopic)
 		defer consumer.Close()
 
 		go consumer.Start(ctx)
@@ -60,6 +79,9 @@ func main() {
 	http.HandleFunc("/", orderHandler.ShowHomePage)
 	http.HandleFunc("/order/", orderHandler.ShowOrder)
 	http.HandleFunc("/api/order/", orderHandler.GetOrderJSON)
+	http.HandleFunc("/api/orders", orderHandler.SearchOrdersJSON)
+	http.HandleFunc("/api/cache/stats", orderHandler.GetCacheStatsJSON)
+	http.HandleFunc("/api/item-statuses", orderHandler.GetItemStatusesJSON)
 
 	server := &http.Server{
 		Addr:         ":" + cfg.HTTPPort,
@@ -87,5 +109,44 @@ func main() {
 		log.Fatal("Server forced to shutdown:", err)
 	}
 
+	if err := orderService.SaveCacheSnapshot(shutdownCtx); err != nil {
+		log.Printf("Warning: failed to save cache snapshot: %v", err)
+	}
+
 	log.Println("Server exited properly")
 }
+
+func newOrderCache(cfg *config.Config) (interfaces.Cache, error) {
+	if cfg.CacheBackend != config.CacheBackendRedis {
+		return cache.NewCacheWithOptions(cfg.Cache)
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", cfg.Redis.Addr, err)
+	}
+
+	log.Printf("Using redis cache at %s", cfg.Redis.Addr)
+	shared, err := cache.NewRedisCache(client, cfg.Redis.KeyPrefix, cfg.Cache)
+	if err != nil || cfg.Redis.L1TTL <= 0 {
+		return shared, err
+	}
+
+	if cfg.Redis.L1TTL >= cfg.Cache.TTL {
+		return nil, fmt.Errorf("CACHE_L1_TTL %s must be shorter than CACHE_TTL %s", cfg.Redis.L1TTL, cfg.Cache.TTL)
+	}
+	l1Opts := cfg.Cache
+	l1Opts.TTL = cfg.Redis.L1TTL
+	l1Opts.CleanupInterval = min(cfg.Cache.CleanupInterval, cfg.Redis.L1TTL)
+
+	log.Printf("Using local L1 cache with TTL %s in front of redis", cfg.Redis.L1TTL)
+	return cache.NewTieredCache(shared, l1Opts)
+}
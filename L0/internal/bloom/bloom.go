@@ -0,0 +1,84 @@
+package bloom
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// Filter is a thread-safe Bloom filter over strings. MayContain never returns
+// false for a key that was added; it may return true for keys that were not.
+type Filter struct {
+	mu     sync.RWMutex
+	bits   []uint64
+	m      uint64
+	k      uint64
+	length int
+}
+
+// New sizes a filter for expectedItems keys at the given false-positive rate.
+func New(expectedItems int, falsePositiveRate float64) *Filter {
+	if expectedItems < 1 {
+		expectedItems = 1
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		falsePositiveRate = 0.01
+	}
+
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+
+	words := (uint64(m) + 63) / 64
+	return &Filter{
+		bits: make([]uint64, words),
+		m:    words * 64,
+		k:    uint64(k),
+	}
+}
+
+func (f *Filter) Add(key string) {
+	h1, h2 := hashes(key)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		f.bits[bit/64] |= 1 << (bit % 64)
+	}
+	f.length++
+}
+
+func (f *Filter) MayContain(key string) bool {
+	h1, h2 := hashes(key)
+
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	for i := uint64(0); i < f.k; i++ {
+		bit := (h1 + i*h2) % f.m
+		if f.bits[bit/64]&(1<<(bit%64)) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Len reports how many keys have been added, counting duplicates.
+func (f *Filter) Len() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.length
+}
+
+// hashes derives two independent hashes for double hashing (Kirsch–Mitzenmacher).
+func hashes(key string) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+
+	h1 := sum & 0xffffffff
+	h2 := sum>>32 | 1
+	return h1, h2
+}
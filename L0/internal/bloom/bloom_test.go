@@ -0,0 +1,45 @@
+package bloom
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_NoFalseNegatives(t *testing.T) {
+	filter := New(1000, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		filter.Add(fmt.Sprintf("order-%d", i))
+	}
+
+	for i := 0; i < 1000; i++ {
+		assert.True(t, filter.MayContain(fmt.Sprintf("order-%d", i)))
+	}
+	assert.Equal(t, 1000, filter.Len())
+}
+
+func TestFilter_FalsePositiveRate(t *testing.T) {
+	filter := New(1000, 0.01)
+
+	for i := 0; i < 1000; i++ {
+		filter.Add(fmt.Sprintf("order-%d", i))
+	}
+
+	falsePositives := 0
+	for i := 0; i < 10000; i++ {
+		if filter.MayContain(fmt.Sprintf("missing-%d", i)) {
+			falsePositives++
+		}
+	}
+
+	assert.Less(t, falsePositives, 300, "false positive rate is far above the configured 1%%")
+}
+
+func TestFilter_Empty(t *testing.T) {
+	filter := New(0, 0)
+
+	assert.False(t, filter.MayContain("order-1"))
+	assert.Equal(t, 0, filter.Len())
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func orderWithItems(uid string, items int) *models.Order {
+	order := &models.Order{OrderUID: uid, Items: make([]models.Item, items)}
+	for i := range order.Items {
+		order.Items[i].Name = strings.Repeat("x", 100)
+	}
+	return order
+}
+
+func newBudgetCache(t *testing.T, maxBytes int64) *Cache {
+	t.Helper()
+
+	opts := DefaultOptions()
+	opts.MaxBytes = maxBytes
+	cache, _ := newTestCache(t, opts)
+	return cache
+}
+
+func TestCache_ByteBudgetEvictsLeastRecentlyUsed(t *testing.T) {
+	small := estimateOrderSize(orderWithItems("order-0", 1))
+	cache := newBudgetCache(t, 3*small)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, cache.Set(t.Context(), orderWithItems(fmt.Sprintf("order-%d", i), 1)))
+	}
+	cache.Get(t.Context(), "order-0")
+
+	require.NoError(t, cache.Set(t.Context(), orderWithItems("order-3", 1)))
+
+	stats := cache.Stats()
+	assert.Equal(t, 3, stats.Size)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.LessOrEqual(t, stats.MemoryBytes, 3*small)
+
+	_, exists, _ := cache.Get(t.Context(), "order-1")
+	assert.False(t, exists, "least recently used order should be evicted")
+	_, exists, _ = cache.Get(t.Context(), "order-0")
+	assert.True(t, exists)
+}
+
+func TestCache_ByteBudgetLargeOrderEvictsSeveral(t *testing.T) {
+	small := estimateOrderSize(orderWithItems("order-0", 1))
+	cache := newBudgetCache(t, 4*small)
+
+	for i := 0; i < 4; i++ {
+		require.NoError(t, cache.Set(t.Context(), orderWithItems(fmt.Sprintf("order-%d", i), 1)))
+	}
+
+	large := orderWithItems("large", 3)
+	require.NoError(t, cache.Set(t.Context(), large))
+
+	stats := cache.Stats()
+	assert.LessOrEqual(t, stats.MemoryBytes, 4*small)
+	assert.Greater(t, stats.Evictions, uint64(1))
+
+	_, exists, _ := cache.Get(t.Context(), "large")
+	assert.True(t, exists)
+}
+
+func TestCache_ByteBudgetRefusesOversizedOrder(t *testing.T) {
+	small := estimateOrderSize(orderWithItems("order-0", 1))
+	cache := newBudgetCache(t, 2*small)
+
+	require.NoError(t, cache.Set(t.Context(), orderWithItems("order-0", 1)))
+
+	err := cache.Set(t.Context(), orderWithItems("huge", 50))
+	require.ErrorIs(t, err, ErrOrderTooLarge)
+
+	stats := cache.Stats()
+	assert.Equal(t, 1, stats.Size, "existing entries must survive a refused order")
+	assert.Equal(t, uint64(0), stats.Evictions)
+	assert.Equal(t, small, stats.MemoryBytes)
+}
+
+func TestCache_ByteBudgetOverwriteGrowth(t *testing.T) {
+	small := estimateOrderSize(orderWithItems("order-0", 1))
+	cache := newBudgetCache(t, 3*small)
+
+	require.NoError(t, cache.Set(t.Context(), orderWithItems("order-0", 1)))
+	require.NoError(t, cache.Set(t.Context(), orderWithItems("order-1", 1)))
+	require.NoError(t, cache.Set(t.Context(), orderWithItems("order-0", 2)))
+
+	stats := cache.Stats()
+	assert.LessOrEqual(t, stats.MemoryBytes, 3*small)
+	_, exists, _ := cache.Get(t.Context(), "order-0")
+	assert.True(t, exists, "the overwritten order itself must not be evicted")
+}
+
+func TestEstimateOrderSize_GrowsWithContent(t *testing.T) {
+	assert.Greater(t, estimateOrderSize(orderWithItems("order", 10)), estimateOrderSize(orderWithItems("order", 1)))
+	assert.Greater(t, estimateOrderSize(&models.Order{OrderUID: strings.Repeat("u", 100)}), estimateOrderSize(&models.Order{OrderUID: "u"}))
+}
+
+func TestCache_ByteBudgetRefusedOverwriteDropsStaleCopy(t *testing.T) {
+	small := estimateOrderSize(orderWithItems("order-0", 1))
+	cache := newBudgetCache(t, 2*small)
+
+	require.NoError(t, cache.Set(t.Context(), orderWithItems("order-0", 1)))
+
+	err := cache.Set(t.Context(), orderWithItems("order-0", 50))
+	require.ErrorIs(t, err, ErrOrderTooLarge)
+
+	_, exists, _ := cache.Get(t.Context(), "order-0")
+	assert.False(t, exists)
+	assert.Equal(t, int64(0), cache.Stats().MemoryBytes)
+}
@@ -5,118 +5,112 @@ import (
 	"L0/internal/models"
 	"context"
 	"fmt"
-	"log"
-	"sync"
 	"time"
 )
 
 var _ interfaces.Cache = (*Cache)(nil)
 
+var ErrOrderTooLarge = ErrValueTooLarge
+
+// Cache is the order instantiation of TTLCache. On top of the generic
+// expiry and eviction it keeps orders with an older Version from replacing
+// newer ones and publishes cache events.
 type Cache struct {
-	mu         sync.RWMutex
-	orders     map[string]*cacheEntry
-	maxSize    int
-	dafaultTTL time.Duration
-}
-type cacheEntry struct {
-	order      *models.Order
-	expiresAt  time.Time
-	lastAccess time.Time
+	*TTLCache[string, *models.Order]
+	events *eventBus
 }
 
+const (
+	defaultMaxSize         = 1000
+	defaultTTL             = 10 * time.Minute
+	defaultCleanupInterval = 3 * time.Minute
+	defaultMaxLifetime     = time.Hour
+)
+
 func NewCache() interfaces.Cache {
-	return &Cache{
-		orders:     make(map[string]*cacheEntry),
-		maxSize:    1000,
-		dafaultTTL: 10 * time.Minute,
-	}
+	return newCache(DefaultOptions())
 }
 
-func (c *Cache) Set(order *models.Order) error {
-	if order == nil || order.OrderUID == "" {
-		return fmt.Errorf("invalid order")
-	}
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	c.orders[order.OrderUID] = &cacheEntry{
-		order:      order,
-		expiresAt:  time.Now().Add(c.dafaultTTL),
-		lastAccess: time.Now(),
+func NewCacheWithOptions(opts Options) (interfaces.Cache, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cache options: %w", err)
 	}
-	return nil
+	return newCache(opts), nil
 }
 
-func (c *Cache) Get(orderUID string) (*models.Order, bool) {
-	if orderUID == "" {
-		return nil, false
+func newCache(opts Options) *Cache {
+	c := &Cache{
+		TTLCache: newTTLCache[string](opts, estimateOrderSize),
+		events:   newEventBus(),
 	}
+	c.onInsert = c.onOrderInsert
+	c.onRemove = c.onOrderRemove
+	c.afterUnlock = func() { c.events.flush() }
+	c.supersedes = func(current, next *models.Order) bool { return current.Version > next.Version }
+	return c
+}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *Cache) Set(ctx context.Context, order *models.Order) error {
+	_, err := c.setVersioned(ctx, order)
+	return err
+}
 
-	entry, exists := c.orders[orderUID]
-	if !exists {
-		return nil, false
+func (c *Cache) setVersioned(ctx context.Context, order *models.Order) (bool, error) {
+	if order == nil || order.OrderUID == "" {
+		return false, fmt.Errorf("invalid order")
 	}
-
-	if time.Now().After(entry.expiresAt) {
-		delete(c.orders, orderUID)
-		return nil, false
+	if err := ctx.Err(); err != nil {
+		return false, err
 	}
-
-	entry.lastAccess = time.Now()
-	return entry.order, true
+	stored, err := c.TTLCache.set(order.OrderUID, order)
+	if err != nil {
+		return false, fmt.Errorf("order %s: %w", order.OrderUID, err)
+	}
+	return stored, nil
 }
 
-func (c *Cache) GetAll() []*models.Order {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-
-	orders := make([]*models.Order, 0, len(c.orders))
-	now := time.Now()
-
-	for _, entry := range c.orders {
-		if now.After(entry.expiresAt) {
-			continue
-		}
-		orders = append(orders, entry.order)
+func (c *Cache) Get(ctx context.Context, orderUID string) (*models.Order, bool, error) {
+	if orderUID == "" {
+		return nil, false, nil
 	}
+	if err := ctx.Err(); err != nil {
+		return nil, false, err
+	}
+	order, exists := c.TTLCache.Get(orderUID)
+	return order, exists, nil
+}
 
-	return orders
+// Delete, DeleteMany and Purge ignore ctx: dropping an in-memory entry
+// cannot block, and a stale copy must go even when the caller gave up.
+func (c *Cache) Delete(_ context.Context, orderUID string) bool {
+	if orderUID == "" {
+		return false
+	}
+	return c.TTLCache.Delete(orderUID)
 }
 
-func (c *Cache) Cleanup() {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+func (c *Cache) DeleteMany(_ context.Context, orderUIDs []string) int {
+	return c.TTLCache.DeleteMany(orderUIDs)
+}
 
-	now := time.Now()
+func (c *Cache) Purge(_ context.Context) int {
+	return c.TTLCache.Purge()
+}
 
-	for orderUID, entry := range c.orders {
-		if now.After(entry.expiresAt) {
-			delete(c.orders, orderUID)
-		}
+func (c *Cache) GetAll(ctx context.Context) ([]*models.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-
+	return c.all(), nil
 }
 
-func (c *Cache) StartCleanupWorker(ctx context.Context) {
-	ticker := time.NewTicker(3 * time.Minute)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			c.Cleanup()
-		case <-ctx.Done():
-			log.Println("Cleanup worker stopped")
-			return
-		}
-	}
+// all returns the unexpired orders, newest first.
+func (c *Cache) all() []*models.Order {
+	orders := c.Values()
+	sortNewestFirst(orders)
+	return orders
 }
 
 func (c *Cache) Size() int {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	return len(c.orders)
+	return c.Len()
 }
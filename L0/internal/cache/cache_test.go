@@ -1,8 +1,11 @@
 package cache
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"L0/internal/clock"
 	"L0/internal/models"
 
 	"github.com/stretchr/testify/assert"
@@ -17,10 +20,10 @@ func TestCache_SetAndGet(t *testing.T) {
 		Entry:       "WB",
 	}
 
-	err := cache.Set(order)
+	err := cache.Set(t.Context(), order)
 	require.NoError(t, err)
 
-	retrieved, exists := cache.Get("testUID")
+	retrieved, exists, _ := cache.Get(t.Context(), "testUID")
 	require.True(t, exists)
 	assert.Equal(t, order.OrderUID, retrieved.OrderUID)
 	assert.Equal(t, order.TrackNumber, retrieved.TrackNumber)
@@ -29,7 +32,7 @@ func TestCache_SetAndGet(t *testing.T) {
 func TestCache_GetNonExistent(t *testing.T) {
 	cache := NewCache()
 
-	order, exists := cache.Get("non-existent")
+	order, exists, _ := cache.Get(t.Context(), "non-existent")
 	assert.False(t, exists)
 	assert.Nil(t, order)
 }
@@ -40,10 +43,11 @@ func TestCache_GetAll(t *testing.T) {
 	order1 := &models.Order{OrderUID: "order-1"}
 	order2 := &models.Order{OrderUID: "order-2"}
 
-	_ = cache.Set(order1)
-	_ = cache.Set(order2)
+	_ = cache.Set(t.Context(), order1)
+	_ = cache.Set(t.Context(), order2)
 
-	all := cache.GetAll()
+	all, err := cache.GetAll(t.Context())
+	require.NoError(t, err)
 	assert.Len(t, all, 2)
 }
 
@@ -52,34 +56,251 @@ func TestCache_Size(t *testing.T) {
 
 	assert.Equal(t, 0, cache.Size())
 
-	_ = cache.Set(&models.Order{OrderUID: "order-1"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1"})
 	assert.Equal(t, 1, cache.Size())
 
-	_ = cache.Set(&models.Order{OrderUID: "order-2"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
 	assert.Equal(t, 2, cache.Size())
 }
 
 func TestCache_SetInvalidOrder(t *testing.T) {
 	cache := NewCache()
 
-	err := cache.Set(nil)
+	err := cache.Set(t.Context(), nil)
 	assert.Error(t, err)
 
-	err = cache.Set(&models.Order{OrderUID: ""})
+	err = cache.Set(t.Context(), &models.Order{OrderUID: ""})
 	assert.Error(t, err)
 }
 
+func TestCache_CancelledContext(t *testing.T) {
+	cache := NewCache()
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order"}))
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	assert.ErrorIs(t, cache.Set(ctx, &models.Order{OrderUID: "other"}), context.Canceled)
+
+	_, exists, err := cache.Get(ctx, "order")
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, exists)
+
+	_, err = cache.GetAll(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, cache.Size())
+}
+
 func TestCache_Cleanup(t *testing.T) {
 	cache := NewCache()
 
 	order := &models.Order{OrderUID: "test-order"}
-	_ = cache.Set(order)
+	_ = cache.Set(t.Context(), order)
 
-	_, exists := cache.Get("test-order")
+	_, exists, _ := cache.Get(t.Context(), "test-order")
 	assert.True(t, exists)
 
 	cache.Cleanup()
 
-	_, exists = cache.Get("test-order")
+	_, exists, _ = cache.Get(t.Context(), "test-order")
+	assert.True(t, exists)
+}
+
+func TestCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := NewCache().(*Cache)
+	cache.maxSize = 2
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+
+	_, exists, _ := cache.Get(t.Context(), "order-1")
+	require.True(t, exists)
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-3"})
+
+	assert.Equal(t, 2, cache.Size())
+	assert.Equal(t, uint64(1), cache.Stats().Evictions)
+
+	_, exists, _ = cache.Get(t.Context(), "order-2")
+	assert.False(t, exists)
+	_, exists, _ = cache.Get(t.Context(), "order-1")
+	assert.True(t, exists)
+	_, exists, _ = cache.Get(t.Context(), "order-3")
+	assert.True(t, exists)
+}
+
+func TestCache_OverwriteDoesNotEvict(t *testing.T) {
+	cache := NewCache().(*Cache)
+	cache.maxSize = 2
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1", TrackNumber: "updated"})
+
+	assert.Equal(t, 2, cache.Size())
+	assert.Equal(t, uint64(0), cache.Stats().Evictions)
+
+	order, exists, _ := cache.Get(t.Context(), "order-1")
+	require.True(t, exists)
+	assert.Equal(t, "updated", order.TrackNumber)
+}
+
+func TestCache_KeepsNewerVersion(t *testing.T) {
+	cache, fake := newTestCache(t, DefaultOptions())
+
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "v2", Version: 2}))
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "v1", Version: 1}))
+
+	order, exists, _ := cache.Get(t.Context(), "order")
+	require.True(t, exists)
+	assert.Equal(t, "v2", order.TrackNumber, "older version must not replace a newer one")
+
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "v2-replaced", Version: 2}))
+	order, _, _ = cache.Get(t.Context(), "order")
+	assert.Equal(t, "v2-replaced", order.TrackNumber, "same version may be rewritten")
+
+	fake.Advance(defaultTTL + time.Second)
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "v1", Version: 1}))
+	order, exists, _ = cache.Get(t.Context(), "order")
+	require.True(t, exists)
+	assert.Equal(t, "v1", order.TrackNumber, "expired entries do not block older versions")
+}
+
+func TestCache_Stats(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxEntries = 2
+	cache, clock := newTestCache(t, opts)
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1", Items: []models.Item{{Name: "item"}}})
+	clock.Advance(time.Minute)
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+
+	cache.Get(t.Context(), "order-1")
+	cache.Get(t.Context(), "order-1")
+	cache.Get(t.Context(), "non-existent")
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-3"})
+
+	clock.Advance(9*time.Minute + time.Nanosecond)
+	cache.Get(t.Context(), "order-1")
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(2), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Expirations)
+	assert.Equal(t, uint64(1), stats.Evictions)
+	assert.Equal(t, 1, stats.Size)
+	assert.Equal(t, estimateOrderSize(&models.Order{OrderUID: "order-3"}), stats.MemoryBytes)
+}
+
+func TestCache_TTLBoundary(t *testing.T) {
+	cache, clock := newTestCache(t, DefaultOptions())
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order"})
+
+	clock.Advance(defaultTTL)
+	_, exists, _ := cache.Get(t.Context(), "order")
+	assert.True(t, exists, "entry must still be valid exactly at its expiry time")
+
+	clock.Advance(time.Nanosecond)
+	_, exists, _ = cache.Get(t.Context(), "order")
+	assert.False(t, exists, "entry must expire right after its TTL")
+	assert.Equal(t, 0, cache.Size())
+}
+
+func TestCache_SetRefreshesTTL(t *testing.T) {
+	cache, clock := newTestCache(t, DefaultOptions())
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order"})
+	clock.Advance(defaultTTL - time.Second)
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order"})
+
+	clock.Advance(defaultTTL)
+	_, exists, _ := cache.Get(t.Context(), "order")
 	assert.True(t, exists)
 }
+
+func TestCache_GetAllSkipsExpired(t *testing.T) {
+	cache, clock := newTestCache(t, DefaultOptions())
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1"})
+	clock.Advance(time.Minute)
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+
+	clock.Advance(defaultTTL - time.Minute + time.Nanosecond)
+
+	all, err := cache.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Equal(t, "order-2", all[0].OrderUID)
+}
+
+func TestCache_CleanupCountsExpirations(t *testing.T) {
+	cache, clock := newTestCache(t, DefaultOptions())
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1"})
+	clock.Advance(time.Minute)
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+
+	clock.Advance(defaultTTL - time.Minute)
+	cache.Cleanup()
+	assert.Equal(t, 2, cache.Size())
+
+	clock.Advance(time.Nanosecond)
+	cache.Cleanup()
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Expirations)
+	assert.Equal(t, 1, stats.Size)
+	assert.Equal(t, estimateOrderSize(&models.Order{OrderUID: "order-2"}), stats.MemoryBytes)
+}
+
+func newTestCache(t *testing.T, opts Options) (*Cache, *clock.Fake) {
+	t.Helper()
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	opts.Clock = fake
+
+	c, err := NewCacheWithOptions(opts)
+	require.NoError(t, err)
+
+	return c.(*Cache), fake
+}
+
+func TestCache_Delete(t *testing.T) {
+	cache, _ := newTestCache(t, DefaultOptions())
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1", TrackNumber: "track"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+
+	assert.True(t, cache.Delete(t.Context(), "order-1"))
+	assert.False(t, cache.Delete(t.Context(), "order-1"))
+	assert.False(t, cache.Delete(t.Context(), ""))
+
+	_, exists, _ := cache.Get(t.Context(), "order-1")
+	assert.False(t, exists)
+	assert.Equal(t, estimateOrderSize(&models.Order{OrderUID: "order-2"}), cache.Stats().MemoryBytes)
+}
+
+func TestCache_DeleteManyAndPurge(t *testing.T) {
+	cache, _ := newTestCache(t, DefaultOptions())
+
+	for _, uid := range []string{"order-1", "order-2", "order-3", "order-4"} {
+		_ = cache.Set(t.Context(), &models.Order{OrderUID: uid, CustomerID: "customer"})
+	}
+
+	assert.Equal(t, 2, cache.DeleteMany(t.Context(), []string{"order-1", "order-2", "missing"}))
+	assert.Equal(t, 2, cache.Size())
+
+	events, unsubscribe := cache.Subscribe(10)
+	defer unsubscribe()
+
+	assert.Equal(t, 2, cache.Purge(t.Context()))
+	assert.Equal(t, 0, cache.Size())
+	assert.Equal(t, int64(0), cache.Stats().MemoryBytes)
+
+	got := drainEvents(events)
+	require.Len(t, got, 2)
+	assert.Equal(t, EventDeleted, got[0].Kind)
+	assert.Equal(t, "purged", got[0].Reason)
+}
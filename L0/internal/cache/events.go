@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"L0/internal/models"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const defaultEventBuffer = 64
+
+type EventKind int
+
+const (
+	EventSet EventKind = iota
+	EventOverwrite
+	EventExpired
+	EventEvicted
+	EventDeleted
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventSet:
+		return "set"
+	case EventOverwrite:
+		return "overwrite"
+	case EventExpired:
+		return "expired"
+	case EventEvicted:
+		return "evicted"
+	case EventDeleted:
+		return "deleted"
+	default:
+		return "unknown"
+	}
+}
+
+func (r removalReason) String() string {
+	switch r {
+	case removedExpired:
+		return "ttl"
+	case removedEvicted:
+		return "capacity"
+	case removedOverBudget:
+		return "memory budget"
+	case removedReplaced:
+		return "replaced"
+	case removedTooLarge:
+		return "too large"
+	case removedDeleted:
+		return "deleted"
+	case removedPurged:
+		return "purged"
+	default:
+		return "unknown"
+	}
+}
+
+// Event describes an order entering or leaving the cache. Order is the
+// cached value and must not be modified by subscribers.
+type Event struct {
+	Kind     EventKind
+	OrderUID string
+	// Reason says why an order left the cache; empty for set and overwrite.
+	Reason string
+	Time   time.Time
+	Order  *models.Order
+	// Bytes is the estimated size of the order, CacheSize and CacheBytes
+	// describe the cache right after the event.
+	Bytes      int64
+	CacheSize  int
+	CacheBytes int64
+}
+
+// eventBus collects events while the cache mutex is held and hands them to
+// subscribers once it is released. Sends never block: events for a full
+// subscriber channel are dropped and counted.
+type eventBus struct {
+	mu      sync.Mutex
+	subs    map[chan Event]struct{}
+	pending []Event
+	active  atomic.Int32
+	dropped atomic.Uint64
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{subs: make(map[chan Event]struct{})}
+}
+
+func (b *eventBus) enabled() bool {
+	return b.active.Load() > 0
+}
+
+func (b *eventBus) publish(e Event) {
+	b.mu.Lock()
+	b.pending = append(b.pending, e)
+	b.mu.Unlock()
+}
+
+func (b *eventBus) flush() {
+	if !b.enabled() {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, e := range b.pending {
+		for ch := range b.subs {
+			select {
+			case ch <- e:
+			default:
+				b.dropped.Add(1)
+			}
+		}
+	}
+	clear(b.pending)
+	b.pending = b.pending[:0]
+}
+
+func (b *eventBus) subscribe(buffer int) (<-chan Event, func()) {
+	if buffer <= 0 {
+		buffer = defaultEventBuffer
+	}
+	ch := make(chan Event, buffer)
+
+	b.mu.Lock()
+	b.subs[ch] = struct{}{}
+	b.active.Add(1)
+	b.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			delete(b.subs, ch)
+			close(ch)
+			if b.active.Add(-1) == 0 {
+				clear(b.pending)
+				b.pending = b.pending[:0]
+			}
+		})
+	}
+}
+
+// Subscribe returns a channel of cache events and a function that stops the
+// subscription and closes the channel. buffer <= 0 uses a default size.
+// A subscriber that falls behind loses events rather than slowing the cache;
+// see DroppedEvents.
+func (c *Cache) Subscribe(buffer int) (<-chan Event, func()) {
+	return c.events.subscribe(buffer)
+}
+
+// DroppedEvents reports how many events were discarded because a
+// subscriber's channel was full.
+func (c *Cache) DroppedEvents() uint64 {
+	return c.events.dropped.Load()
+}
+
+// onOrderInsert is the TTLCache hook for new and overwritten orders. Caller must hold c.mu.
+func (c *Cache) onOrderInsert(e *entry[string, *models.Order], overwrite bool) {
+	if !c.events.enabled() {
+		return
+	}
+
+	kind := EventSet
+	if overwrite {
+		kind = EventOverwrite
+	}
+	c.events.publish(c.newEvent(kind, e, ""))
+}
+
+// onOrderRemove is the TTLCache hook for orders leaving the cache. Caller must hold c.mu.
+func (c *Cache) onOrderRemove(e *entry[string, *models.Order], reason removalReason) {
+	if reason == removedReplaced || !c.events.enabled() {
+		return
+	}
+
+	kind := EventDeleted
+	switch reason {
+	case removedExpired:
+		kind = EventExpired
+	case removedEvicted, removedOverBudget:
+		kind = EventEvicted
+	}
+	c.events.publish(c.newEvent(kind, e, reason.String()))
+}
+
+func (c *Cache) newEvent(kind EventKind, e *entry[string, *models.Order], reason string) Event {
+	return Event{
+		Kind:       kind,
+		OrderUID:   e.key,
+		Reason:     reason,
+		Time:       c.clock.Now(),
+		Order:      e.value,
+		Bytes:      e.size,
+		CacheSize:  len(c.items),
+		CacheBytes: c.bytes,
+	}
+}
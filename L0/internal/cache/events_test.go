@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func drainEvents(ch <-chan Event) []Event {
+	var events []Event
+	for {
+		select {
+		case e := <-ch:
+			events = append(events, e)
+		default:
+			return events
+		}
+	}
+}
+
+func TestCache_EventsForSetOverwriteAndEvict(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxEntries = 2
+	cache, _ := newTestCache(t, opts)
+
+	events, unsubscribe := cache.Subscribe(10)
+	defer unsubscribe()
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1", TrackNumber: "updated"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-3"})
+
+	got := drainEvents(events)
+	require.Len(t, got, 5)
+	assert.Equal(t, EventSet, got[0].Kind)
+	assert.Equal(t, EventOverwrite, got[1].Kind)
+	assert.Equal(t, "updated", got[1].Order.TrackNumber)
+	assert.Equal(t, EventSet, got[2].Kind)
+	assert.Equal(t, EventEvicted, got[3].Kind)
+	assert.Equal(t, "order-1", got[3].OrderUID)
+	assert.Equal(t, "capacity", got[3].Reason)
+	assert.Equal(t, EventSet, got[4].Kind)
+	assert.Equal(t, "order-3", got[4].OrderUID)
+	assert.Equal(t, 2, got[4].CacheSize)
+	assert.Equal(t, estimateOrderSize(&models.Order{OrderUID: "order-3"}), got[4].Bytes)
+}
+
+func TestCache_EventsForExpiry(t *testing.T) {
+	cache, clock := newTestCache(t, DefaultOptions())
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-1", TrackNumber: "track"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-3"})
+
+	events, unsubscribe := cache.Subscribe(10)
+	defer unsubscribe()
+
+	clock.Advance(defaultTTL + time.Nanosecond)
+	cache.Get(t.Context(), "order-1")
+	cache.Cleanup()
+
+	got := drainEvents(events)
+	require.Len(t, got, 3)
+	for _, e := range got {
+		assert.Equal(t, EventExpired, e.Kind)
+		assert.Equal(t, "ttl", e.Reason)
+		assert.Equal(t, clock.Now(), e.Time)
+	}
+	assert.Equal(t, "order-1", got[0].OrderUID)
+	assert.Equal(t, 0, got[2].CacheSize)
+}
+
+func TestCache_EventForOversizedReplacement(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxBytes = estimateOrderSize(&models.Order{OrderUID: "order"}) + 10
+	cache, _ := newTestCache(t, opts)
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order"})
+
+	events, unsubscribe := cache.Subscribe(10)
+	defer unsubscribe()
+
+	err := cache.Set(t.Context(), &models.Order{OrderUID: "order", Items: make([]models.Item, 10)})
+	require.ErrorIs(t, err, ErrOrderTooLarge)
+
+	got := drainEvents(events)
+	require.Len(t, got, 1)
+	assert.Equal(t, EventDeleted, got[0].Kind)
+	assert.Equal(t, "too large", got[0].Reason)
+}
+
+func TestCache_SlowSubscriberDoesNotBlock(t *testing.T) {
+	cache, _ := newTestCache(t, DefaultOptions())
+
+	slow, unsubscribeSlow := cache.Subscribe(1)
+	defer unsubscribeSlow()
+	fast, unsubscribeFast := cache.Subscribe(10)
+	defer unsubscribeFast()
+
+	for _, uid := range []string{"order-1", "order-2", "order-3"} {
+		require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: uid}))
+	}
+
+	assert.Len(t, drainEvents(slow), 1)
+	assert.Len(t, drainEvents(fast), 3)
+	assert.Equal(t, uint64(2), cache.DroppedEvents())
+}
+
+func TestCache_UnsubscribeClosesChannel(t *testing.T) {
+	cache, _ := newTestCache(t, DefaultOptions())
+
+	events, unsubscribe := cache.Subscribe(0)
+	unsubscribe()
+	unsubscribe()
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order"})
+
+	_, open := <-events
+	assert.False(t, open)
+	assert.Empty(t, cache.events.pending)
+}
+
+func TestShardedCache_EventsFromAllShards(t *testing.T) {
+	cache, err := NewShardedCache(4, DefaultOptions())
+	require.NoError(t, err)
+	sharded := cache.(*ShardedCache)
+
+	events, unsubscribe := sharded.Subscribe(100)
+	defer unsubscribe()
+
+	for i := 0; i < 20; i++ {
+		_ = cache.Set(t.Context(), &models.Order{OrderUID: string(rune('a' + i))})
+	}
+
+	assert.Len(t, drainEvents(events), 20)
+}
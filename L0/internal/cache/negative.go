@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"L0/internal/clock"
+	"sync"
+	"time"
+)
+
+// NegativeCache remembers order UIDs that were confirmed absent in the
+// database, so repeated lookups for them can skip the query for a short while.
+type NegativeCache struct {
+	mu         sync.Mutex
+	misses     map[string]time.Time
+	ttl        time.Duration
+	maxEntries int
+	clock      clock.Clock
+}
+
+func NewNegativeCache(ttl time.Duration, maxEntries int, clk clock.Clock) *NegativeCache {
+	if clk == nil {
+		clk = clock.Real()
+	}
+
+	return &NegativeCache{
+		misses:     make(map[string]time.Time),
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		clock:      clk,
+	}
+}
+
+func (n *NegativeCache) Add(orderUID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := n.clock.Now()
+	if len(n.misses) >= n.maxEntries {
+		n.removeExpired(now)
+	}
+	if len(n.misses) >= n.maxEntries {
+		// Still full of live entries: drop everything rather than track
+		// recency here. Worst case a few lookups reach the DB again.
+		n.misses = make(map[string]time.Time)
+	}
+
+	n.misses[orderUID] = now.Add(n.ttl)
+}
+
+func (n *NegativeCache) Contains(orderUID string) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	expiresAt, exists := n.misses[orderUID]
+	if !exists {
+		return false
+	}
+
+	if n.clock.Now().After(expiresAt) {
+		delete(n.misses, orderUID)
+		return false
+	}
+
+	return true
+}
+
+func (n *NegativeCache) Remove(orderUID string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.misses, orderUID)
+}
+
+func (n *NegativeCache) Clear() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.misses = make(map[string]time.Time)
+}
+
+func (n *NegativeCache) Size() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return len(n.misses)
+}
+
+// removeExpired drops stale misses. Caller must hold n.mu.
+func (n *NegativeCache) removeExpired(now time.Time) {
+	for orderUID, expiresAt := range n.misses {
+		if now.After(expiresAt) {
+			delete(n.misses, orderUID)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"L0/internal/clock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegativeCache_ExpiresAfterTTL(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	negative := NewNegativeCache(30*time.Second, 10, fake)
+
+	negative.Add("missing")
+	assert.True(t, negative.Contains("missing"))
+	assert.False(t, negative.Contains("other"))
+
+	fake.Advance(30 * time.Second)
+	assert.True(t, negative.Contains("missing"))
+
+	fake.Advance(time.Nanosecond)
+	assert.False(t, negative.Contains("missing"))
+	assert.Equal(t, 0, negative.Size())
+}
+
+func TestNegativeCache_Remove(t *testing.T) {
+	negative := NewNegativeCache(time.Minute, 10, nil)
+
+	negative.Add("missing")
+	negative.Remove("missing")
+
+	assert.False(t, negative.Contains("missing"))
+}
+
+func TestNegativeCache_Bounded(t *testing.T) {
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	negative := NewNegativeCache(time.Minute, 2, fake)
+
+	negative.Add("missing-1")
+	fake.Advance(2 * time.Minute)
+	negative.Add("missing-2")
+	negative.Add("missing-3")
+
+	assert.Equal(t, 2, negative.Size())
+	assert.False(t, negative.Contains("missing-1"))
+	assert.True(t, negative.Contains("missing-3"))
+
+	negative.Add("missing-4")
+	assert.LessOrEqual(t, negative.Size(), 2)
+	assert.True(t, negative.Contains("missing-4"))
+}
+
+func TestNegativeCache_Clear(t *testing.T) {
+	negative := NewNegativeCache(time.Minute, 10, nil)
+
+	negative.Add("missing-1")
+	negative.Add("missing-2")
+	negative.Clear()
+
+	assert.Equal(t, 0, negative.Size())
+	assert.False(t, negative.Contains("missing-1"))
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"L0/internal/clock"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type ExpirationMode int
+
+const (
+	// ExpirationAbsolute expires an entry TTL after it was last written.
+	ExpirationAbsolute ExpirationMode = iota
+	// ExpirationSliding pushes the expiry forward by TTL on every read, but
+	// never past MaxLifetime after the entry was written.
+	ExpirationSliding
+)
+
+func (m ExpirationMode) String() string {
+	switch m {
+	case ExpirationAbsolute:
+		return "absolute"
+	case ExpirationSliding:
+		return "sliding"
+	default:
+		return fmt.Sprintf("ExpirationMode(%d)", int(m))
+	}
+}
+
+func ParseExpirationMode(s string) (ExpirationMode, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "absolute":
+		return ExpirationAbsolute, nil
+	case "sliding":
+		return ExpirationSliding, nil
+	default:
+		return 0, fmt.Errorf("unknown expiration mode %q", s)
+	}
+}
+
+type Options struct {
+	TTL        time.Duration
+	MaxEntries int
+	// MaxBytes caps the estimated memory held by cached orders. Zero
+	// disables the byte budget and only MaxEntries applies.
+	MaxBytes        int64
+	CleanupInterval time.Duration
+	Expiration      ExpirationMode
+	MaxLifetime     time.Duration
+	// Clock defaults to the system clock when nil.
+	Clock clock.Clock
+}
+
+func DefaultOptions() Options {
+	return Options{
+		TTL:             defaultTTL,
+		MaxEntries:      defaultMaxSize,
+		CleanupInterval: defaultCleanupInterval,
+		Expiration:      ExpirationAbsolute,
+		MaxLifetime:     defaultMaxLifetime,
+	}
+}
+
+func (o Options) Validate() error {
+	if o.TTL <= 0 {
+		return fmt.Errorf("cache TTL must be positive, got %s", o.TTL)
+	}
+
+	if o.MaxEntries <= 0 {
+		return fmt.Errorf("cache max entries must be positive, got %d", o.MaxEntries)
+	}
+
+	if o.MaxBytes < 0 {
+		return fmt.Errorf("cache max bytes cannot be negative, got %d", o.MaxBytes)
+	}
+
+	if o.CleanupInterval <= 0 {
+		return fmt.Errorf("cache cleanup interval must be positive, got %s", o.CleanupInterval)
+	}
+
+	if o.CleanupInterval > o.TTL {
+		return fmt.Errorf("cache cleanup interval %s exceeds TTL %s", o.CleanupInterval, o.TTL)
+	}
+
+	if o.Expiration != ExpirationAbsolute && o.Expiration != ExpirationSliding {
+		return fmt.Errorf("unknown expiration mode %s", o.Expiration)
+	}
+
+	if o.Expiration == ExpirationSliding && o.MaxLifetime < o.TTL {
+		return fmt.Errorf("cache max lifetime %s is shorter than TTL %s", o.MaxLifetime, o.TTL)
+	}
+
+	return nil
+}
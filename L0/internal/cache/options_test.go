@@ -0,0 +1,77 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptions_Validate(t *testing.T) {
+	require.NoError(t, DefaultOptions().Validate())
+
+	testCases := []struct {
+		name   string
+		modify func(*Options)
+		errMsg string
+	}{
+		{"zero TTL", func(o *Options) { o.TTL = 0 }, "TTL must be positive"},
+		{"negative max entries", func(o *Options) { o.MaxEntries = -1 }, "max entries must be positive"},
+		{"negative max bytes", func(o *Options) { o.MaxBytes = -1 }, "max bytes cannot be negative"},
+		{"zero cleanup interval", func(o *Options) { o.CleanupInterval = 0 }, "cleanup interval must be positive"},
+		{"cleanup slower than TTL", func(o *Options) { o.CleanupInterval = o.TTL + time.Second }, "exceeds TTL"},
+		{"unknown expiration", func(o *Options) { o.Expiration = ExpirationMode(42) }, "unknown expiration mode"},
+		{"sliding lifetime below TTL", func(o *Options) {
+			o.Expiration = ExpirationSliding
+			o.MaxLifetime = o.TTL - time.Second
+		}, "max lifetime"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts := DefaultOptions()
+			tc.modify(&opts)
+
+			err := opts.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.errMsg)
+
+			_, err = NewCacheWithOptions(opts)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestNewCacheWithOptions(t *testing.T) {
+	opts := Options{
+		TTL:             time.Minute,
+		MaxEntries:      5,
+		CleanupInterval: 30 * time.Second,
+		Expiration:      ExpirationSliding,
+		MaxLifetime:     time.Hour,
+	}
+
+	c, err := NewCacheWithOptions(opts)
+	require.NoError(t, err)
+
+	cache := c.(*Cache)
+	assert.Equal(t, 5, cache.maxSize)
+	assert.Equal(t, time.Minute, cache.dafaultTTL)
+	assert.Equal(t, 30*time.Second, cache.cleanupInterval)
+	assert.Equal(t, ExpirationSliding, cache.expiration)
+	assert.Equal(t, time.Hour, cache.maxLifetime)
+}
+
+func TestParseExpirationMode(t *testing.T) {
+	mode, err := ParseExpirationMode("Sliding")
+	require.NoError(t, err)
+	assert.Equal(t, ExpirationSliding, mode)
+
+	mode, err = ParseExpirationMode("absolute")
+	require.NoError(t, err)
+	assert.Equal(t, ExpirationAbsolute, mode)
+
+	_, err = ParseExpirationMode("forever")
+	assert.Error(t, err)
+}
@@ -0,0 +1,153 @@
+package cache
+
+import (
+	"L0/internal/interfaces"
+	"L0/internal/models"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// Cursor directions. After/Before exclude the key itself; From/Through
+// include it and are only produced when stepping back from an empty page.
+const (
+	cursorAfter   = "a"
+	cursorBefore  = "b"
+	cursorFrom    = "f"
+	cursorThrough = "t"
+)
+
+type cursorKey struct {
+	dateCreated time.Time
+	orderUID    string
+}
+
+// less reports whether order a is listed before b: newest first, then by UID.
+func less(a, b cursorKey) bool {
+	if !a.dateCreated.Equal(b.dateCreated) {
+		return a.dateCreated.After(b.dateCreated)
+	}
+	return a.orderUID < b.orderUID
+}
+
+func keyOf(order *models.Order) cursorKey {
+	return cursorKey{dateCreated: order.DateCreated, orderUID: order.OrderUID}
+}
+
+func encodeCursor(direction string, key cursorKey) string {
+	raw := direction + "|" + strconv.FormatInt(key.dateCreated.UnixNano(), 10) + "|" + key.orderUID
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(cursor string) (string, cursorKey, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", cursorKey{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	parts := strings.SplitN(string(raw), "|", 3)
+	if len(parts) != 3 {
+		return "", cursorKey{}, fmt.Errorf("invalid cursor")
+	}
+	switch parts[0] {
+	case cursorAfter, cursorBefore, cursorFrom, cursorThrough:
+	default:
+		return "", cursorKey{}, fmt.Errorf("invalid cursor")
+	}
+
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return "", cursorKey{}, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return parts[0], cursorKey{dateCreated: time.Unix(0, nanos), orderUID: parts[2]}, nil
+}
+
+// Paginate returns one page of orders, which must already be sorted with
+// sortNewestFirst. An empty cursor starts at the newest order.
+func Paginate(orders []*models.Order, cursor string, limit int) (interfaces.OrderPage, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	start, end := 0, min(limit, len(orders))
+	var key cursorKey
+	if cursor != "" {
+		var (
+			direction string
+			err       error
+		)
+		direction, key, err = decodeCursor(cursor)
+		if err != nil {
+			return interfaces.OrderPage{}, err
+		}
+
+		switch direction {
+		case cursorAfter:
+			start = sort.Search(len(orders), func(i int) bool { return less(key, keyOf(orders[i])) })
+			end = min(start+limit, len(orders))
+		case cursorFrom:
+			start = sort.Search(len(orders), func(i int) bool { return !less(keyOf(orders[i]), key) })
+			end = min(start+limit, len(orders))
+		case cursorBefore:
+			end = sort.Search(len(orders), func(i int) bool { return !less(keyOf(orders[i]), key) })
+			start = max(end-limit, 0)
+		case cursorThrough:
+			end = sort.Search(len(orders), func(i int) bool { return less(key, keyOf(orders[i])) })
+			start = max(end-limit, 0)
+		}
+	}
+
+	page := interfaces.OrderPage{Orders: orders[start:end:end]}
+	if start == end {
+		// Nothing at this position: step back/forward from the cursor itself.
+		if start > 0 {
+			page.PrevCursor = encodeCursor(cursorThrough, key)
+		}
+		if end < len(orders) {
+			page.NextCursor = encodeCursor(cursorFrom, key)
+		}
+		return page, nil
+	}
+
+	if start > 0 {
+		page.PrevCursor = encodeCursor(cursorBefore, keyOf(orders[start]))
+	}
+	if end < len(orders) {
+		page.NextCursor = encodeCursor(cursorAfter, keyOf(orders[end-1]))
+	}
+	return page, nil
+}
+
+func (c *Cache) List(ctx context.Context, cursor string, limit int) (interfaces.OrderPage, error) {
+	if err := ctx.Err(); err != nil {
+		return interfaces.OrderPage{}, err
+	}
+	return Paginate(c.all(), cursor, limit)
+}
+
+func (c *ShardedCache) List(ctx context.Context, cursor string, limit int) (interfaces.OrderPage, error) {
+	if err := ctx.Err(); err != nil {
+		return interfaces.OrderPage{}, err
+	}
+	return Paginate(c.all(), cursor, limit)
+}
+
+func sortNewestFirst(orders []*models.Order) {
+	sort.Slice(orders, func(i, j int) bool {
+		if !orders[i].DateCreated.Equal(orders[j].DateCreated) {
+			return orders[i].DateCreated.After(orders[j].DateCreated)
+		}
+		return orders[i].OrderUID < orders[j].OrderUID
+	})
+}
@@ -0,0 +1,160 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"L0/internal/interfaces"
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPagedCache(t *testing.T, count int) *Cache {
+	t.Helper()
+
+	cache := NewCache().(*Cache)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i := 0; i < count; i++ {
+		// Pairs of orders share a timestamp to exercise the UID tie-break.
+		_ = cache.Set(t.Context(), &models.Order{
+			OrderUID:    fmt.Sprintf("order-%02d", i),
+			DateCreated: base.Add(time.Duration(i/2) * time.Minute),
+		})
+	}
+	return cache
+}
+
+func mustGetAll(t *testing.T, cache interfaces.Cache) []*models.Order {
+	t.Helper()
+
+	orders, err := cache.GetAll(t.Context())
+	require.NoError(t, err)
+	return orders
+}
+
+func uidsOf(orders []*models.Order) []string {
+	uids := make([]string, 0, len(orders))
+	for _, order := range orders {
+		uids = append(uids, order.OrderUID)
+	}
+	return uids
+}
+
+func TestCache_GetAllSortedNewestFirst(t *testing.T) {
+	cache := newPagedCache(t, 4)
+
+	assert.Equal(t, []string{"order-02", "order-03", "order-00", "order-01"}, uidsOf(mustGetAll(t, cache)))
+}
+
+func TestCache_ListForwardAndBackward(t *testing.T) {
+	cache := newPagedCache(t, 7)
+	all := uidsOf(mustGetAll(t, cache))
+
+	var pages [][]string
+	page := mustList(t, cache, "", 3)
+	assert.Empty(t, page.PrevCursor)
+	for {
+		pages = append(pages, uidsOf(page.Orders))
+		if page.NextCursor == "" {
+			break
+		}
+		page = mustList(t, cache, page.NextCursor, 3)
+	}
+
+	require.Len(t, pages, 3)
+	assert.Equal(t, all[0:3], pages[0])
+	assert.Equal(t, all[3:6], pages[1])
+	assert.Equal(t, all[6:7], pages[2])
+
+	page = mustList(t, cache, page.PrevCursor, 3)
+	assert.Equal(t, all[3:6], uidsOf(page.Orders))
+	page = mustList(t, cache, page.PrevCursor, 3)
+	assert.Equal(t, all[0:3], uidsOf(page.Orders))
+	assert.Empty(t, page.PrevCursor)
+	assert.NotEmpty(t, page.NextCursor)
+}
+
+func TestCache_ListStableAcrossCalls(t *testing.T) {
+	cache := newPagedCache(t, 10)
+
+	first := mustList(t, cache, "", 5)
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, uidsOf(first.Orders), uidsOf(mustList(t, cache, "", 5).Orders))
+	}
+}
+
+func TestCache_ListDefaultsAndLimits(t *testing.T) {
+	cache := newPagedCache(t, MaxPageSize+5)
+
+	assert.Len(t, mustList(t, cache, "", 0).Orders, DefaultPageSize)
+	assert.Len(t, mustList(t, cache, "", MaxPageSize+1).Orders, MaxPageSize)
+}
+
+func TestCache_ListInvalidCursor(t *testing.T) {
+	cache := newPagedCache(t, 3)
+
+	_, err := cache.List(t.Context(), "not-a-cursor!", 2)
+	assert.Error(t, err)
+
+	_, err = cache.List(t.Context(), "eHl6", 2)
+	assert.Error(t, err)
+}
+
+func TestCache_ListCanceledContext(t *testing.T) {
+	cache := newPagedCache(t, 3)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	_, err := cache.List(ctx, "", 2)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestCache_ListEmpty(t *testing.T) {
+	page := mustList(t, NewCache().(*Cache), "", 10)
+
+	assert.Empty(t, page.Orders)
+	assert.Empty(t, page.NextCursor)
+	assert.Empty(t, page.PrevCursor)
+}
+
+func TestCache_ListCursorPastRemovedOrder(t *testing.T) {
+	cache := newPagedCache(t, 4)
+
+	page := mustList(t, cache, "", 4)
+	last := page.Orders[3]
+	next := encodeCursor(cursorAfter, keyOf(last))
+
+	empty := mustList(t, cache, next, 2)
+	assert.Empty(t, empty.Orders)
+	assert.Empty(t, empty.NextCursor)
+
+	back := mustList(t, cache, empty.PrevCursor, 2)
+	assert.Equal(t, uidsOf(page.Orders[2:4]), uidsOf(back.Orders))
+}
+
+func mustList(t *testing.T, cache *Cache, cursor string, limit int) interfaces.OrderPage {
+	t.Helper()
+
+	page, err := cache.List(t.Context(), cursor, limit)
+	require.NoError(t, err)
+	return page
+}
+
+func TestCache_ListCursorBeforeFirstOrder(t *testing.T) {
+	cache := newPagedCache(t, 4)
+
+	page := mustList(t, cache, "", 4)
+	prev := encodeCursor(cursorBefore, keyOf(page.Orders[0]))
+
+	empty := mustList(t, cache, prev, 2)
+	assert.Empty(t, empty.Orders)
+	assert.Empty(t, empty.PrevCursor)
+
+	forward := mustList(t, cache, empty.NextCursor, 2)
+	assert.Equal(t, uidsOf(page.Orders[0:2]), uidsOf(forward.Orders))
+}
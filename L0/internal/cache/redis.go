@@ -0,0 +1,388 @@
+package cache
+
+import (
+	"L0/internal/clock"
+	"L0/internal/interfaces"
+	"L0/internal/models"
+	"bytes"
+	"context"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+var _ interfaces.Cache = (*RedisCache)(nil)
+
+const (
+	DefaultRedisKeyPrefix = "l0:"
+	redisScanCount        = 256
+	redisBatchSize        = 100
+	// redisSetAttempts bounds retries of a Set whose order key was changed
+	// by another writer mid-update.
+	redisSetAttempts = 5
+)
+
+// RedisCache keeps orders in a Redis-protocol server so every replica shares
+// one cache. Orders are gob-encoded under <prefix>order:<uid> with the cache
+// TTL. Two sorted sets index them: one ordered like List pages, one scored
+// by expiry time so the cleanup worker can prune entries the server expired.
+// MaxEntries and MaxBytes are left to the server's maxmemory policy.
+type RedisCache struct {
+	client          redis.UniversalClient
+	prefix          string
+	ttl             time.Duration
+	cleanupInterval time.Duration
+	clock           clock.Clock
+	hits            atomic.Uint64
+	misses          atomic.Uint64
+	// size counts indexed orders. Writes through this replica adjust it and
+	// every prune resets it from the server, so writes by other replicas
+	// show up within one cleanup interval.
+	size atomic.Int64
+}
+
+func NewRedisCache(client redis.UniversalClient, keyPrefix string, opts Options) (interfaces.Cache, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cache options: %w", err)
+	}
+	if opts.Expiration != ExpirationAbsolute {
+		return nil, fmt.Errorf("redis cache supports only %s expiration, got %s", ExpirationAbsolute, opts.Expiration)
+	}
+	// Purge deletes every key under the prefix; without one it would wipe
+	// keys that belong to other applications.
+	if keyPrefix == "" {
+		return nil, fmt.Errorf("redis key prefix cannot be empty")
+	}
+	if opts.Clock == nil {
+		opts.Clock = clock.Real()
+	}
+
+	return &RedisCache{
+		client:          client,
+		prefix:          keyPrefix,
+		ttl:             opts.TTL,
+		cleanupInterval: opts.CleanupInterval,
+		clock:           opts.Clock,
+	}, nil
+}
+
+func (c *RedisCache) orderKey(orderUID string) string {
+	return c.prefix + "order:" + orderUID
+}
+
+func (c *RedisCache) Set(ctx context.Context, order *models.Order) error {
+	_, err := c.setVersioned(ctx, order)
+	return err
+}
+
+func (c *RedisCache) setVersioned(ctx context.Context, order *models.Order) (bool, error) {
+	if order == nil || order.OrderUID == "" {
+		return false, fmt.Errorf("invalid order")
+	}
+
+	data, err := encodeOrder(order)
+	if err != nil {
+		return false, err
+	}
+	member := indexMember(keyOf(order))
+
+	// WATCH makes the version check and the write one atomic step; a
+	// concurrent write to the key aborts the transaction and we retry.
+	var stored bool
+	var added int64
+	update := func(tx *redis.Tx) error {
+		stored, added = false, 0
+		old, err := c.loadFrom(ctx, tx, order.OrderUID)
+		if err != nil {
+			return err
+		}
+		if old != nil && order.Version < old.Version {
+			return nil
+		}
+
+		var removed, indexed *redis.IntCmd
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, c.orderKey(order.OrderUID), data, c.ttl)
+			// A new DateCreated moves the order within the index.
+			if old != nil {
+				if oldMember := indexMember(keyOf(old)); oldMember != member {
+					removed = pipe.ZRem(ctx, c.createdKey(), oldMember)
+					pipe.ZRem(ctx, c.expiryKey(), oldMember)
+				}
+			}
+			indexed = pipe.ZAdd(ctx, c.createdKey(), redis.Z{Member: member})
+			pipe.ZAdd(ctx, c.expiryKey(), redis.Z{Score: c.expiryScore(), Member: member})
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+
+		stored = true
+		added = indexed.Val()
+		if removed != nil {
+			added -= removed.Val()
+		}
+		return nil
+	}
+
+	for attempt := 0; attempt < redisSetAttempts; attempt++ {
+		err = c.client.Watch(ctx, update, c.orderKey(order.OrderUID))
+		if !errors.Is(err, redis.TxFailedErr) {
+			break
+		}
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to store order %s in redis: %w", order.OrderUID, err)
+	}
+	c.size.Add(added)
+	return stored, nil
+}
+
+func (c *RedisCache) Get(ctx context.Context, orderUID string) (*models.Order, bool, error) {
+	if orderUID == "" {
+		return nil, false, nil
+	}
+
+	order, err := c.load(ctx, orderUID)
+	if err != nil {
+		c.misses.Add(1)
+		return nil, false, err
+	}
+	if order == nil {
+		c.misses.Add(1)
+		return nil, false, nil
+	}
+
+	c.hits.Add(1)
+	return order, true, nil
+}
+
+func (c *RedisCache) Delete(ctx context.Context, orderUID string) bool {
+	if orderUID == "" {
+		return false
+	}
+	return c.DeleteMany(ctx, []string{orderUID}) == 1
+}
+
+// DeleteMany reads the orders first to find their index entries. An entry
+// missed because its order expired is pruned by the cleanup worker.
+func (c *RedisCache) DeleteMany(ctx context.Context, orderUIDs []string) int {
+	deleted := 0
+
+	for start := 0; start < len(orderUIDs); start += redisBatchSize {
+		batch := orderUIDs[start:min(start+redisBatchSize, len(orderUIDs))]
+		keys := make([]string, 0, len(batch))
+		for _, orderUID := range batch {
+			if orderUID != "" {
+				keys = append(keys, c.orderKey(orderUID))
+			}
+		}
+		if len(keys) == 0 {
+			continue
+		}
+
+		orders, err := c.loadKeys(ctx, keys)
+		if err != nil {
+			log.Printf("Redis cache: failed to delete orders: %v", err)
+			continue
+		}
+		members := make([]any, 0, len(orders))
+		for _, order := range orders {
+			members = append(members, indexMember(keyOf(order)))
+		}
+
+		var del, unindexed *redis.IntCmd
+		_, err = c.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			del = pipe.Del(ctx, keys...)
+			if len(members) > 0 {
+				unindexed = pipe.ZRem(ctx, c.createdKey(), members...)
+				pipe.ZRem(ctx, c.expiryKey(), members...)
+			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("Redis cache: failed to delete orders: %v", err)
+			continue
+		}
+		deleted += int(del.Val())
+		if unindexed != nil {
+			c.size.Add(-unindexed.Val())
+		}
+	}
+
+	return deleted
+}
+
+// Purge removes every key under the cache prefix and returns how many orders
+// were dropped.
+func (c *RedisCache) Purge(ctx context.Context) int {
+	purged, err := c.countKeys(ctx, c.orderKey("*"))
+	if err != nil {
+		log.Printf("Redis cache: %v", err)
+	}
+
+	err = c.scan(ctx, c.prefix+"*", func(keys []string) error {
+		return c.client.Del(ctx, keys...).Err()
+	})
+	if err != nil {
+		log.Printf("Redis cache: failed to purge: %v", err)
+	}
+	c.size.Store(0)
+
+	return purged
+}
+
+// GetAll walks the index, so it is already sorted newest first.
+func (c *RedisCache) GetAll(ctx context.Context) ([]*models.Order, error) {
+	members, err := c.client.ZRangeByLex(ctx, c.createdKey(), &redis.ZRangeBy{Min: "-", Max: "+"}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list orders: %w", err)
+	}
+
+	orders := make([]*models.Order, 0, len(members))
+	for start := 0; start < len(members); start += redisBatchSize {
+		batch, err := c.loadMembers(ctx, members[start:min(start+redisBatchSize, len(members))])
+		if err != nil {
+			return nil, fmt.Errorf("failed to list orders: %w", err)
+		}
+		orders = append(orders, batch...)
+	}
+	return orders, nil
+}
+
+// Size reports the counter kept next to the index instead of asking the
+// server; see RedisCache.size.
+func (c *RedisCache) Size() int {
+	return int(c.size.Load())
+}
+
+// Stats reports hits and misses seen by this replica. Expirations and
+// evictions happen inside the server and are not counted.
+func (c *RedisCache) Stats() interfaces.CacheStats {
+	return interfaces.CacheStats{
+		Hits:   c.hits.Load(),
+		Misses: c.misses.Load(),
+		Size:   c.Size(),
+	}
+}
+
+// Cleanup does nothing: the server expires orders itself, and the cleanup
+// worker prunes the index with its own context.
+func (c *RedisCache) Cleanup() {}
+
+func (c *RedisCache) StartCleanupWorker(ctx context.Context) {
+	ticker := time.NewTicker(c.cleanupInterval)
+	defer ticker.Stop()
+
+	c.pruneAndLog(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			c.pruneAndLog(ctx)
+		case <-ctx.Done():
+			log.Println("Cleanup worker stopped")
+			return
+		}
+	}
+}
+
+func (c *RedisCache) pruneAndLog(ctx context.Context) {
+	if err := c.prune(ctx); err != nil && ctx.Err() == nil {
+		log.Printf("Redis cache: %v", err)
+	}
+}
+
+// load returns nil without an error when orderUID is not cached.
+func (c *RedisCache) load(ctx context.Context, orderUID string) (*models.Order, error) {
+	return c.loadFrom(ctx, c.client, orderUID)
+}
+
+func (c *RedisCache) loadFrom(ctx context.Context, client redis.Cmdable, orderUID string) (*models.Order, error) {
+	data, err := client.Get(ctx, c.orderKey(orderUID)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order %s from redis: %w", orderUID, err)
+	}
+	return decodeOrder(data)
+}
+
+func (c *RedisCache) loadKeys(ctx context.Context, keys []string) ([]*models.Order, error) {
+	values, err := c.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders from redis: %w", err)
+	}
+
+	orders := make([]*models.Order, 0, len(values))
+	for _, value := range values {
+		order, err := decodeValue(value)
+		if err != nil {
+			log.Printf("Redis cache: %v", err)
+		}
+		if order != nil {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+// scan calls fn with batches of keys matching pattern.
+func (c *RedisCache) scan(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	var cursor uint64
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, pattern, redisScanCount).Result()
+		if err != nil {
+			return fmt.Errorf("failed to scan %s: %w", pattern, err)
+		}
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+func (c *RedisCache) countKeys(ctx context.Context, pattern string) (int, error) {
+	count := 0
+	err := c.scan(ctx, pattern, func(keys []string) error {
+		count += len(keys)
+		return nil
+	})
+	return count, err
+}
+
+func encodeOrder(order *models.Order) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(order); err != nil {
+		return nil, fmt.Errorf("failed to encode order %s: %w", order.OrderUID, err)
+	}
+	return buf.Bytes(), nil
+}
+
+func decodeOrder(data []byte) (*models.Order, error) {
+	order := &models.Order{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(order); err != nil {
+		return nil, fmt.Errorf("failed to decode cached order: %w", err)
+	}
+	return order, nil
+}
+
+// decodeValue decodes one MGET result; missing keys come back as nil.
+func decodeValue(value any) (*models.Order, error) {
+	data, ok := value.(string)
+	if !ok {
+		return nil, nil
+	}
+	return decodeOrder([]byte(data))
+}
@@ -0,0 +1,240 @@
+package cache
+
+import (
+	"L0/internal/interfaces"
+	"L0/internal/models"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Index members are "<rank>|<uid>" with every score 0, so the server orders
+// them by bytes. rank is a fixed-width inversion of DateCreated, which makes
+// that byte order the List order: newest first, then by UID.
+const indexRankWidth = 20
+
+// pruneScript drops index entries whose orders the server has expired and
+// returns how many orders remain indexed. Running it as one script keeps a
+// concurrent Set from re-adding an entry between the read and the removal.
+var pruneScript = redis.NewScript(`
+local expired = redis.call('ZRANGEBYSCORE', KEYS[2], '-inf', ARGV[1])
+for i = 1, #expired, 100 do
+	local batch = {unpack(expired, i, math.min(i + 99, #expired))}
+	redis.call('ZREM', KEYS[1], unpack(batch))
+	redis.call('ZREM', KEYS[2], unpack(batch))
+end
+return redis.call('ZCARD', KEYS[1])
+`)
+
+func (c *RedisCache) createdKey() string {
+	return c.prefix + "index:created"
+}
+
+func (c *RedisCache) expiryKey() string {
+	return c.prefix + "index:expiry"
+}
+
+// expiryScore is when an order written now expires, in Unix milliseconds.
+func (c *RedisCache) expiryScore() float64 {
+	return float64(c.clock.Now().Add(c.ttl).UnixMilli())
+}
+
+func indexMember(key cursorKey) string {
+	// Flipping the sign bit orders int64 like uint64; inverting puts the
+	// newest first.
+	rank := ^(uint64(key.dateCreated.UnixNano()) ^ (1 << 63))
+	return fmt.Sprintf("%0*d|%s", indexRankWidth, rank, key.orderUID)
+}
+
+func parseIndexMember(member string) (cursorKey, error) {
+	rawRank, orderUID, ok := strings.Cut(member, "|")
+	if !ok || len(rawRank) != indexRankWidth {
+		return cursorKey{}, fmt.Errorf("malformed index entry %q", member)
+	}
+	rank, err := strconv.ParseUint(rawRank, 10, 64)
+	if err != nil {
+		return cursorKey{}, fmt.Errorf("malformed index entry %q: %w", member, err)
+	}
+	nanos := int64(^rank ^ (1 << 63))
+	return cursorKey{dateCreated: time.Unix(0, nanos), orderUID: orderUID}, nil
+}
+
+func (c *RedisCache) prune(ctx context.Context) error {
+	now := strconv.FormatInt(c.clock.Now().UnixMilli(), 10)
+	size, err := pruneScript.Run(ctx, c.client, []string{c.createdKey(), c.expiryKey()}, now).Int64()
+	if err != nil {
+		return fmt.Errorf("failed to prune index: %w", err)
+	}
+	c.size.Store(size)
+	return nil
+}
+
+// List reads one page from the index, using the same cursors as Paginate.
+// Orders expired since the last prune are pruned first; one that expires
+// mid-call is left out, so a page can come back short.
+func (c *RedisCache) List(ctx context.Context, cursor string, limit int) (interfaces.OrderPage, error) {
+	if limit <= 0 {
+		limit = DefaultPageSize
+	}
+	limit = min(limit, MaxPageSize)
+
+	// An empty cursor reads forward from the start.
+	direction, bound := cursorFrom, "-"
+	var key cursorKey
+	if cursor != "" {
+		var err error
+		direction, key, err = decodeCursor(cursor)
+		if err != nil {
+			return interfaces.OrderPage{}, err
+		}
+		bound = lexBound(direction, indexMember(key))
+	}
+
+	if err := c.prune(ctx); err != nil {
+		return interfaces.OrderPage{}, err
+	}
+
+	forward := direction == cursorAfter || direction == cursorFrom
+	members, more, err := c.readIndex(ctx, forward, bound, limit)
+	if err != nil {
+		return interfaces.OrderPage{}, err
+	}
+
+	var page interfaces.OrderPage
+	if len(members) == 0 {
+		page.Orders = []*models.Order{}
+		if cursor == "" {
+			return page, nil
+		}
+		// Nothing at this position: step back/forward from the cursor itself.
+		var found bool
+		if forward {
+			if found, err = c.anyBefore(ctx, invertLexBound(bound)); found {
+				page.PrevCursor = encodeCursor(cursorThrough, key)
+			}
+		} else {
+			if found, err = c.anyAfter(ctx, invertLexBound(bound)); found {
+				page.NextCursor = encodeCursor(cursorFrom, key)
+			}
+		}
+		if err != nil {
+			return interfaces.OrderPage{}, err
+		}
+		return page, nil
+	}
+
+	first, err := parseIndexMember(members[0])
+	if err != nil {
+		return interfaces.OrderPage{}, err
+	}
+	last, err := parseIndexMember(members[len(members)-1])
+	if err != nil {
+		return interfaces.OrderPage{}, err
+	}
+
+	if forward {
+		if more {
+			page.NextCursor = encodeCursor(cursorAfter, last)
+		}
+		if cursor != "" {
+			if more, err = c.anyBefore(ctx, "("+members[0]); err != nil {
+				return interfaces.OrderPage{}, err
+			}
+			if more {
+				page.PrevCursor = encodeCursor(cursorBefore, first)
+			}
+		}
+	} else {
+		if more {
+			page.PrevCursor = encodeCursor(cursorBefore, first)
+		}
+		if more, err = c.anyAfter(ctx, "("+members[len(members)-1]); err != nil {
+			return interfaces.OrderPage{}, err
+		}
+		if more {
+			page.NextCursor = encodeCursor(cursorAfter, last)
+		}
+	}
+
+	page.Orders, err = c.loadMembers(ctx, members)
+	if err != nil {
+		return interfaces.OrderPage{}, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return page, nil
+}
+
+// readIndex returns up to limit members in List order starting at bound, and
+// whether more lie beyond them in the direction read.
+func (c *RedisCache) readIndex(ctx context.Context, forward bool, bound string, limit int) ([]string, bool, error) {
+	var members []string
+	var err error
+	if forward {
+		members, err = c.client.ZRangeByLex(ctx, c.createdKey(), &redis.ZRangeBy{
+			Min: bound, Max: "+", Count: int64(limit + 1),
+		}).Result()
+	} else {
+		members, err = c.client.ZRevRangeByLex(ctx, c.createdKey(), &redis.ZRangeBy{
+			Min: "-", Max: bound, Count: int64(limit + 1),
+		}).Result()
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read order index: %w", err)
+	}
+
+	more := len(members) > limit
+	members = members[:min(len(members), limit)]
+	if !forward {
+		slices.Reverse(members)
+	}
+	return members, more, nil
+}
+
+// anyBefore reports whether the index holds members up to bound.
+func (c *RedisCache) anyBefore(ctx context.Context, bound string) (bool, error) {
+	n, err := c.client.ZLexCount(ctx, c.createdKey(), "-", bound).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to count order index: %w", err)
+	}
+	return n > 0, nil
+}
+
+// anyAfter reports whether the index holds members from bound on.
+func (c *RedisCache) anyAfter(ctx context.Context, bound string) (bool, error) {
+	n, err := c.client.ZLexCount(ctx, c.createdKey(), bound, "+").Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to count order index: %w", err)
+	}
+	return n > 0, nil
+}
+
+func (c *RedisCache) loadMembers(ctx context.Context, members []string) ([]*models.Order, error) {
+	keys := make([]string, 0, len(members))
+	for _, member := range members {
+		_, orderUID, _ := strings.Cut(member, "|")
+		keys = append(keys, c.orderKey(orderUID))
+	}
+	return c.loadKeys(ctx, keys)
+}
+
+// lexBound turns a cursor direction into the ZRANGEBYLEX bound it starts
+// from: After/Before exclude the member, From/Through include it.
+func lexBound(direction, member string) string {
+	if direction == cursorAfter || direction == cursorBefore {
+		return "(" + member
+	}
+	return "[" + member
+}
+
+// invertLexBound returns the bound covering what bound leaves out on the
+// other side of its member.
+func invertLexBound(bound string) string {
+	if bound[0] == '(' {
+		return "[" + bound[1:]
+	}
+	return "(" + bound[1:]
+}
@@ -0,0 +1,298 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"L0/internal/clock"
+	"L0/internal/models"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestRedisCache(t *testing.T) (*RedisCache, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	c, err := NewRedisCache(client, DefaultRedisKeyPrefix, DefaultOptions())
+	require.NoError(t, err)
+
+	return c.(*RedisCache), server
+}
+
+func TestRedisCache_SetAndGet(t *testing.T) {
+	cache, server := newTestRedisCache(t)
+
+	order := &models.Order{
+		OrderUID:    "testUID",
+		TrackNumber: "TrackNumber",
+		DateCreated: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC),
+		Items:       []models.Item{{ID: 7, Name: "item", Price: 100}},
+	}
+	require.NoError(t, cache.Set(t.Context(), order))
+
+	retrieved, exists, _ := cache.Get(t.Context(), "testUID")
+	require.True(t, exists)
+	assert.Equal(t, order, retrieved)
+
+	_, exists, _ = cache.Get(t.Context(), "non-existent")
+	assert.False(t, exists)
+
+	assert.Equal(t, defaultTTL, server.TTL("l0:order:testUID"))
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, 1, stats.Size)
+}
+
+func TestRedisCache_KeepsNewerVersion(t *testing.T) {
+	cache, _ := newTestRedisCache(t)
+
+	stored, err := cache.setVersioned(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "v2", Version: 2})
+	require.NoError(t, err)
+	assert.True(t, stored)
+	stored, err = cache.setVersioned(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "v1", Version: 1})
+	require.NoError(t, err)
+	assert.False(t, stored)
+
+	order, exists, err := cache.Get(t.Context(), "order")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, "v2", order.TrackNumber)
+
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "v3", Version: 3}))
+	order, _, err = cache.Get(t.Context(), "order")
+	require.NoError(t, err)
+	assert.Equal(t, "v3", order.TrackNumber)
+}
+
+func TestRedisCache_ServerErrors(t *testing.T) {
+	cache, server := newTestRedisCache(t)
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order"}))
+
+	server.SetError("LOADING server is loading")
+	defer server.SetError("")
+
+	_, exists, err := cache.Get(t.Context(), "order")
+	assert.Error(t, err)
+	assert.False(t, exists)
+
+	_, err = cache.GetAll(t.Context())
+	assert.Error(t, err)
+
+	_, err = cache.List(t.Context(), "", 10)
+	assert.Error(t, err)
+}
+
+func TestRedisCache_InvalidInput(t *testing.T) {
+	cache, _ := newTestRedisCache(t)
+
+	assert.Error(t, cache.Set(t.Context(), nil))
+	assert.Error(t, cache.Set(t.Context(), &models.Order{OrderUID: ""}))
+
+	_, exists, _ := cache.Get(t.Context(), "")
+	assert.False(t, exists)
+
+	opts := DefaultOptions()
+	opts.Expiration = ExpirationSliding
+	_, err := NewRedisCache(cache.client, DefaultRedisKeyPrefix, opts)
+	assert.Error(t, err)
+
+	_, err = NewRedisCache(cache.client, "", DefaultOptions())
+	assert.Error(t, err)
+}
+
+func TestRedisCache_Expiry(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	opts := DefaultOptions()
+	opts.Clock = fake
+	c, err := NewRedisCache(client, DefaultRedisKeyPrefix, opts)
+	require.NoError(t, err)
+	cache := c.(*RedisCache)
+
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "track"}))
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "later"}))
+	server.FastForward(defaultTTL / 2)
+	fake.Advance(defaultTTL / 2)
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "later"}))
+	assert.Equal(t, 2, cache.Size())
+
+	server.FastForward(defaultTTL / 2)
+	fake.Advance(defaultTTL / 2)
+	_, exists, _ := cache.Get(t.Context(), "order")
+	assert.False(t, exists)
+
+	require.NoError(t, cache.prune(t.Context()))
+	assert.Equal(t, 1, cache.Size())
+	assert.Equal(t, []string{"later"}, uidsOf(mustGetAll(t, cache)))
+}
+
+func TestRedisCache_ListMatchesPaginate(t *testing.T) {
+	cache, _ := newTestRedisCache(t)
+	local := newPagedCache(t, 9)
+	for _, order := range mustGetAll(t, local) {
+		require.NoError(t, cache.Set(t.Context(), order))
+	}
+	old := &models.Order{OrderUID: "old", DateCreated: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC)}
+	require.NoError(t, cache.Set(t.Context(), old))
+	require.NoError(t, local.Set(t.Context(), old))
+
+	assert.Equal(t, uidsOf(mustGetAll(t, local)), uidsOf(mustGetAll(t, cache)))
+
+	// Follow every cursor both ways and compare each page with Paginate.
+	for _, limit := range []int{1, 2, 4, 20} {
+		seen := map[string]bool{}
+		cursors := []string{""}
+		for len(cursors) > 0 {
+			cursor := cursors[0]
+			cursors = cursors[1:]
+			if seen[cursor] {
+				continue
+			}
+			seen[cursor] = true
+
+			want, err := local.List(t.Context(), cursor, limit)
+			require.NoError(t, err)
+			got, err := cache.List(t.Context(), cursor, limit)
+			require.NoError(t, err)
+
+			assert.Equal(t, uidsOf(want.Orders), uidsOf(got.Orders), "limit %d, cursor %q", limit, cursor)
+			assert.Equal(t, want.NextCursor, got.NextCursor, "limit %d, cursor %q", limit, cursor)
+			assert.Equal(t, want.PrevCursor, got.PrevCursor, "limit %d, cursor %q", limit, cursor)
+			for _, next := range []string{got.NextCursor, got.PrevCursor} {
+				if next != "" {
+					cursors = append(cursors, next)
+				}
+			}
+		}
+	}
+}
+
+func TestRedisCache_ListCursorPastRemovedOrder(t *testing.T) {
+	cache, _ := newTestRedisCache(t)
+	local := newPagedCache(t, 4)
+	for _, order := range mustGetAll(t, local) {
+		require.NoError(t, cache.Set(t.Context(), order))
+	}
+
+	page, err := cache.List(t.Context(), "", 4)
+	require.NoError(t, err)
+	last := page.Orders[3].OrderUID
+	require.True(t, cache.Delete(t.Context(), last))
+	require.True(t, local.Delete(t.Context(), last))
+
+	for _, cursor := range []string{
+		encodeCursor(cursorAfter, keyOf(page.Orders[3])),
+		encodeCursor(cursorBefore, keyOf(page.Orders[0])),
+	} {
+		want, err := local.List(t.Context(), cursor, 2)
+		require.NoError(t, err)
+		got, err := cache.List(t.Context(), cursor, 2)
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRedisCache_IndexFollowsDateCreated(t *testing.T) {
+	cache, _ := newTestRedisCache(t)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "a", DateCreated: base, Version: 1}))
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "b", DateCreated: base.Add(time.Minute), Version: 1}))
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "a", DateCreated: base.Add(time.Hour), Version: 2}))
+
+	assert.Equal(t, []string{"a", "b"}, uidsOf(mustGetAll(t, cache)))
+	assert.Equal(t, 2, cache.Size())
+
+	// An older version is dropped and leaves the index alone.
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "a", DateCreated: base, Version: 1}))
+	assert.Equal(t, []string{"a", "b"}, uidsOf(mustGetAll(t, cache)))
+	assert.Equal(t, 2, cache.Size())
+}
+
+func TestIndexMember_RoundTrip(t *testing.T) {
+	keys := []cursorKey{
+		{dateCreated: time.Date(2025, 1, 1, 12, 0, 0, 123, time.UTC), orderUID: "b"},
+		{dateCreated: time.Date(2025, 1, 1, 12, 0, 0, 123, time.UTC), orderUID: "c"},
+		{dateCreated: time.Date(2025, 1, 1, 11, 0, 0, 0, time.UTC), orderUID: "a"},
+		{dateCreated: time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), orderUID: "a"},
+	}
+	for i, key := range keys {
+		member := indexMember(key)
+		parsed, err := parseIndexMember(member)
+		require.NoError(t, err)
+		assert.True(t, key.dateCreated.Equal(parsed.dateCreated))
+		assert.Equal(t, key.orderUID, parsed.orderUID)
+		if i > 0 {
+			assert.Less(t, indexMember(keys[i-1]), member)
+		}
+	}
+
+	_, err := parseIndexMember("not-a-member")
+	assert.Error(t, err)
+}
+
+func TestRedisCache_GetAllAndList(t *testing.T) {
+	cache, _ := newTestRedisCache(t)
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 5; i++ {
+		_ = cache.Set(t.Context(), &models.Order{OrderUID: fmt.Sprintf("order-%d", i), DateCreated: base.Add(time.Duration(i) * time.Minute)})
+	}
+
+	all, err := cache.GetAll(t.Context())
+	require.NoError(t, err)
+	require.Len(t, all, 5)
+	assert.Equal(t, "order-4", all[0].OrderUID)
+
+	page, err := cache.List(t.Context(), "", 2)
+	require.NoError(t, err)
+	require.Len(t, page.Orders, 2)
+	assert.Equal(t, "order-3", page.Orders[1].OrderUID)
+	assert.NotEmpty(t, page.NextCursor)
+}
+
+func TestRedisCache_DeleteAndPurge(t *testing.T) {
+	cache, server := newTestRedisCache(t)
+
+	for _, uid := range []string{"order-1", "order-2", "order-3", "order-4"} {
+		_ = cache.Set(t.Context(), &models.Order{OrderUID: uid})
+	}
+	require.NoError(t, server.Set("unrelated", "value"))
+
+	assert.True(t, cache.Delete(t.Context(), "order-1"))
+	assert.False(t, cache.Delete(t.Context(), "order-1"))
+	assert.Equal(t, 2, cache.DeleteMany(t.Context(), []string{"order-2", "order-3", "missing"}))
+	assert.Equal(t, 1, cache.Size())
+
+	assert.Equal(t, 1, cache.Purge(t.Context()))
+	assert.Equal(t, 0, cache.Size())
+	assert.False(t, server.Exists("l0:order:order-4"))
+	assert.True(t, server.Exists("unrelated"))
+}
+
+func TestRedisCache_SharedBetweenReplicas(t *testing.T) {
+	first, server := newTestRedisCache(t)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	second, err := NewRedisCache(client, DefaultRedisKeyPrefix, DefaultOptions())
+	require.NoError(t, err)
+
+	require.NoError(t, first.Set(t.Context(), &models.Order{OrderUID: "order"}))
+
+	_, exists, _ := second.Get(t.Context(), "order")
+	assert.True(t, exists)
+}
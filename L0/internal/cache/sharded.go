@@ -0,0 +1,163 @@
+package cache
+
+import (
+	"L0/internal/interfaces"
+	"L0/internal/models"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"time"
+)
+
+var _ interfaces.Cache = (*ShardedCache)(nil)
+
+const DefaultShardCount = 16
+
+// ShardedCache spreads orders across independently locked Cache segments,
+// so operations on different order UIDs rarely contend for the same mutex.
+type ShardedCache struct {
+	shards          []*Cache
+	cleanupInterval time.Duration
+	events          *eventBus
+}
+
+// NewShardedCache splits opts.MaxEntries and opts.MaxBytes evenly across
+// shardCount segments, so the largest cacheable order is MaxBytes/shardCount.
+func NewShardedCache(shardCount int, opts Options) (interfaces.Cache, error) {
+	if shardCount <= 0 {
+		return nil, fmt.Errorf("shard count must be positive, got %d", shardCount)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cache options: %w", err)
+	}
+
+	shardOpts := opts
+	shardOpts.MaxEntries = (opts.MaxEntries + shardCount - 1) / shardCount
+	shardOpts.MaxBytes = (opts.MaxBytes + int64(shardCount) - 1) / int64(shardCount)
+	events := newEventBus()
+	shards := make([]*Cache, shardCount)
+	for i := range shards {
+		shards[i] = newCache(shardOpts)
+		shards[i].events = events
+	}
+
+	return &ShardedCache{shards: shards, cleanupInterval: opts.CleanupInterval, events: events}, nil
+}
+
+func (c *ShardedCache) shardFor(orderUID string) *Cache {
+	h := fnv.New32a()
+	h.Write([]byte(orderUID))
+	return c.shards[h.Sum32()%uint32(len(c.shards))]
+}
+
+func (c *ShardedCache) Set(ctx context.Context, order *models.Order) error {
+	if order == nil || order.OrderUID == "" {
+		return fmt.Errorf("invalid order")
+	}
+	return c.shardFor(order.OrderUID).Set(ctx, order)
+}
+
+func (c *ShardedCache) Get(ctx context.Context, orderUID string) (*models.Order, bool, error) {
+	if orderUID == "" {
+		return nil, false, nil
+	}
+	return c.shardFor(orderUID).Get(ctx, orderUID)
+}
+
+func (c *ShardedCache) Delete(ctx context.Context, orderUID string) bool {
+	if orderUID == "" {
+		return false
+	}
+	return c.shardFor(orderUID).Delete(ctx, orderUID)
+}
+
+func (c *ShardedCache) DeleteMany(ctx context.Context, orderUIDs []string) int {
+	byShard := make(map[*Cache][]string)
+	for _, orderUID := range orderUIDs {
+		shard := c.shardFor(orderUID)
+		byShard[shard] = append(byShard[shard], orderUID)
+	}
+
+	deleted := 0
+	for shard, uids := range byShard {
+		deleted += shard.DeleteMany(ctx, uids)
+	}
+	return deleted
+}
+
+func (c *ShardedCache) Purge(ctx context.Context) int {
+	purged := 0
+	for _, shard := range c.shards {
+		purged += shard.Purge(ctx)
+	}
+	return purged
+}
+
+func (c *ShardedCache) GetAll(ctx context.Context) ([]*models.Order, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.all(), nil
+}
+
+func (c *ShardedCache) all() []*models.Order {
+	var orders []*models.Order
+	for _, shard := range c.shards {
+		orders = append(orders, shard.all()...)
+	}
+	sortNewestFirst(orders)
+	return orders
+}
+
+// Subscribe receives events from every shard; see Cache.Subscribe.
+func (c *ShardedCache) Subscribe(buffer int) (<-chan Event, func()) {
+	return c.events.subscribe(buffer)
+}
+
+func (c *ShardedCache) DroppedEvents() uint64 {
+	return c.events.dropped.Load()
+}
+
+func (c *ShardedCache) Size() int {
+	size := 0
+	for _, shard := range c.shards {
+		size += shard.Size()
+	}
+	return size
+}
+
+func (c *ShardedCache) Stats() interfaces.CacheStats {
+	var total interfaces.CacheStats
+	for _, shard := range c.shards {
+		stats := shard.Stats()
+		total.Hits += stats.Hits
+		total.Misses += stats.Misses
+		total.Expirations += stats.Expirations
+		total.Evictions += stats.Evictions
+		total.Size += stats.Size
+		total.MemoryBytes += stats.MemoryBytes
+	}
+	return total
+}
+
+func (c *ShardedCache) Cleanup() {
+	for _, shard := range c.shards {
+		shard.Cleanup()
+	}
+}
+
+func (c *ShardedCache) StartCleanupWorker(ctx context.Context) {
+	ticker := time.NewTicker(c.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Cleanup()
+		case <-ctx.Done():
+			log.Println("Cleanup worker stopped")
+			return
+		}
+	}
+}
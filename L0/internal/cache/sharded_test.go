@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"fmt"
+	"sync/atomic"
+	"testing"
+
+	"L0/internal/interfaces"
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCache_SetAndGet(t *testing.T) {
+	cache, err := NewShardedCache(4, DefaultOptions())
+	require.NoError(t, err)
+
+	for i := 0; i < 100; i++ {
+		require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: fmt.Sprintf("order-%d", i)}))
+	}
+
+	assert.Equal(t, 100, cache.Size())
+	assert.Len(t, mustGetAll(t, cache), 100)
+
+	order, exists, _ := cache.Get(t.Context(), "order-42")
+	require.True(t, exists)
+	assert.Equal(t, "order-42", order.OrderUID)
+
+	_, exists, _ = cache.Get(t.Context(), "non-existent")
+	assert.False(t, exists)
+}
+
+func TestShardedCache_InvalidInput(t *testing.T) {
+	_, err := NewShardedCache(0, DefaultOptions())
+	assert.Error(t, err)
+
+	cache, err := NewShardedCache(2, DefaultOptions())
+	require.NoError(t, err)
+
+	assert.Error(t, cache.Set(t.Context(), nil))
+	assert.Error(t, cache.Set(t.Context(), &models.Order{OrderUID: ""}))
+
+	_, exists, _ := cache.Get(t.Context(), "")
+	assert.False(t, exists)
+}
+
+func TestShardedCache_SameKeySameShard(t *testing.T) {
+	cache, err := NewShardedCache(8, DefaultOptions())
+	require.NoError(t, err)
+	sharded := cache.(*ShardedCache)
+
+	assert.Same(t, sharded.shardFor("order-1"), sharded.shardFor("order-1"))
+}
+
+func TestShardedCache_Cleanup(t *testing.T) {
+	cache, err := NewShardedCache(4, DefaultOptions())
+	require.NoError(t, err)
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "test-order"})
+	cache.Cleanup()
+
+	_, exists, _ := cache.Get(t.Context(), "test-order")
+	assert.True(t, exists)
+}
+
+func benchmarkParallelMixed(b *testing.B, cache interfaces.Cache) {
+	ctx := b.Context()
+	const keys = 512
+	uids := make([]string, keys)
+	for i := range uids {
+		uids[i] = fmt.Sprintf("order-%d", i)
+		_ = cache.Set(ctx, &models.Order{OrderUID: uids[i]})
+	}
+
+	var seed atomic.Uint64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		n := seed.Add(1) * 7919
+		for pb.Next() {
+			n++
+			uid := uids[n%keys]
+			if n%10 == 0 {
+				_ = cache.Set(ctx, &models.Order{OrderUID: uid})
+			} else {
+				cache.Get(ctx, uid)
+			}
+		}
+	})
+}
+
+func BenchmarkCache_ParallelMixed(b *testing.B) {
+	benchmarkParallelMixed(b, NewCache())
+}
+
+func BenchmarkShardedCache_ParallelMixed(b *testing.B) {
+	cache, err := NewShardedCache(DefaultShardCount, DefaultOptions())
+	require.NoError(b, err)
+	benchmarkParallelMixed(b, cache)
+}
+
+func TestShardedCache_DeleteManyAndPurge(t *testing.T) {
+	cache, err := NewShardedCache(4, DefaultOptions())
+	require.NoError(t, err)
+
+	uids := make([]string, 20)
+	for i := range uids {
+		uids[i] = fmt.Sprintf("order-%d", i)
+		_ = cache.Set(t.Context(), &models.Order{OrderUID: uids[i]})
+	}
+
+	assert.True(t, cache.Delete(t.Context(), "order-0"))
+	assert.Equal(t, 9, cache.DeleteMany(t.Context(), uids[:10]))
+	assert.Equal(t, 10, cache.Size())
+
+	assert.Equal(t, 10, cache.Purge(t.Context()))
+	assert.Equal(t, 0, cache.Size())
+}
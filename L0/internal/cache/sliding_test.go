@@ -0,0 +1,86 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"L0/internal/clock"
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSlidingCache(t *testing.T) (*Cache, *clock.Fake) {
+	t.Helper()
+
+	opts := DefaultOptions()
+	opts.TTL = 10 * time.Minute
+	opts.Expiration = ExpirationSliding
+	opts.MaxLifetime = 30 * time.Minute
+
+	return newTestCache(t, opts)
+}
+
+func TestCache_SlidingExpirationExtendsOnAccess(t *testing.T) {
+	cache, clock := newSlidingCache(t)
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "hot-order"})
+
+	for i := 0; i < 2; i++ {
+		clock.Advance(9 * time.Minute)
+		_, exists, _ := cache.Get(t.Context(), "hot-order")
+		require.True(t, exists, "access %d", i)
+	}
+
+	clock.Advance(9 * time.Minute)
+	_, exists, _ := cache.Get(t.Context(), "hot-order")
+	assert.True(t, exists)
+
+	clock.Advance(10*time.Minute + time.Second)
+	_, exists, _ = cache.Get(t.Context(), "hot-order")
+	assert.False(t, exists)
+}
+
+func TestCache_SlidingExpirationCappedByMaxLifetime(t *testing.T) {
+	cache, clock := newSlidingCache(t)
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "hot-order"})
+
+	for elapsed := 5 * time.Minute; elapsed <= 30*time.Minute; elapsed += 5 * time.Minute {
+		clock.Advance(5 * time.Minute)
+		_, exists, _ := cache.Get(t.Context(), "hot-order")
+		require.True(t, exists, "elapsed %s", elapsed)
+	}
+
+	clock.Advance(time.Second)
+	_, exists, _ := cache.Get(t.Context(), "hot-order")
+	assert.False(t, exists)
+	assert.Equal(t, uint64(1), cache.Stats().Expirations)
+}
+
+func TestCache_SlidingExpirationResetsLifetimeOnSet(t *testing.T) {
+	cache, clock := newSlidingCache(t)
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "hot-order"})
+	clock.Advance(25 * time.Minute)
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "hot-order"})
+
+	clock.Advance(9 * time.Minute)
+	_, exists, _ := cache.Get(t.Context(), "hot-order")
+	assert.True(t, exists)
+}
+
+func TestCache_AbsoluteExpirationIgnoresAccess(t *testing.T) {
+	cache, clock := newTestCache(t, DefaultOptions())
+
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order"})
+
+	clock.Advance(9 * time.Minute)
+	_, exists, _ := cache.Get(t.Context(), "order")
+	require.True(t, exists)
+
+	clock.Advance(time.Minute + time.Second)
+	_, exists, _ = cache.Get(t.Context(), "order")
+	assert.False(t, exists)
+}
@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"L0/internal/interfaces"
+	"L0/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+)
+
+var _ interfaces.Cache = (*TieredCache)(nil)
+
+// TieredCache puts a small in-process Cache (L1) in front of a shared cache
+// (L2). Writes go to both tiers unless L2 keeps a newer version, reads try
+// L1 first and copy L2 hits into it. L2 is authoritative for listings,
+// since L1 only holds recently used orders. Keep the L1 TTL short: it bounds how long a replica
+// can serve an order that another replica has since changed.
+type TieredCache struct {
+	l1 *Cache
+	l2 interfaces.Cache
+}
+
+func NewTieredCache(l2 interfaces.Cache, l1Opts Options) (interfaces.Cache, error) {
+	if l2 == nil {
+		return nil, fmt.Errorf("tiered cache needs an L2 cache")
+	}
+	if err := l1Opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid L1 cache options: %w", err)
+	}
+
+	return &TieredCache{l1: newCache(l1Opts), l2: l2}, nil
+}
+
+// versionedSetter is a cache whose Set can report whether it stored order
+// or kept a newer cached version instead.
+type versionedSetter interface {
+	setVersioned(ctx context.Context, order *models.Order) (bool, error)
+}
+
+// Set writes order to L2 and, if L2 stored it, to L1. When L2 kept a newer
+// version, or cannot tell, the L1 copy is dropped so the next Get promotes
+// whatever L2 holds.
+func (c *TieredCache) Set(ctx context.Context, order *models.Order) error {
+	var stored bool
+	var err error
+	if l2, ok := c.l2.(versionedSetter); ok {
+		stored, err = l2.setVersioned(ctx, order)
+	} else {
+		err = c.l2.Set(ctx, order)
+	}
+	if err != nil {
+		// Drop the local copy so it does not outlive the failed write.
+		if order != nil {
+			c.l1.Delete(ctx, order.OrderUID)
+		}
+		return fmt.Errorf("failed to write L2 cache: %w", err)
+	}
+
+	if !stored {
+		c.l1.Delete(ctx, order.OrderUID)
+		return nil
+	}
+	if err := c.l1.Set(ctx, order); err != nil && !errors.Is(err, ErrOrderTooLarge) {
+		return fmt.Errorf("failed to write L1 cache: %w", err)
+	}
+	return nil
+}
+
+func (c *TieredCache) Get(ctx context.Context, orderUID string) (*models.Order, bool, error) {
+	order, exists, err := c.l1.Get(ctx, orderUID)
+	if exists || err != nil {
+		return order, exists, err
+	}
+
+	order, exists, err = c.l2.Get(ctx, orderUID)
+	if !exists || err != nil {
+		return nil, false, err
+	}
+
+	if err := c.l1.Set(ctx, order); err != nil && !errors.Is(err, ErrOrderTooLarge) {
+		log.Printf("Failed to promote order %s into L1 cache: %v", orderUID, err)
+	}
+	return order, true, nil
+}
+
+func (c *TieredCache) Delete(ctx context.Context, orderUID string) bool {
+	inL1 := c.l1.Delete(ctx, orderUID)
+	return c.l2.Delete(ctx, orderUID) || inL1
+}
+
+func (c *TieredCache) DeleteMany(ctx context.Context, orderUIDs []string) int {
+	c.l1.DeleteMany(ctx, orderUIDs)
+	return c.l2.DeleteMany(ctx, orderUIDs)
+}
+
+func (c *TieredCache) Purge(ctx context.Context) int {
+	c.l1.Purge(ctx)
+	return c.l2.Purge(ctx)
+}
+
+func (c *TieredCache) GetAll(ctx context.Context) ([]*models.Order, error) {
+	return c.l2.GetAll(ctx)
+}
+
+func (c *TieredCache) List(ctx context.Context, cursor string, limit int) (interfaces.OrderPage, error) {
+	return c.l2.List(ctx, cursor, limit)
+}
+
+func (c *TieredCache) Size() int {
+	return c.l2.Size()
+}
+
+// Stats counts a hit in either tier as a hit and a miss in both as a miss.
+// Size is the L2 size; the other counters add up both tiers.
+func (c *TieredCache) Stats() interfaces.CacheStats {
+	l1 := c.l1.Stats()
+	l2 := c.l2.Stats()
+
+	return interfaces.CacheStats{
+		Hits:        l1.Hits + l2.Hits,
+		Misses:      l2.Misses,
+		Expirations: l1.Expirations + l2.Expirations,
+		Evictions:   l1.Evictions + l2.Evictions,
+		Size:        l2.Size,
+		MemoryBytes: l1.MemoryBytes + l2.MemoryBytes,
+	}
+}
+
+func (c *TieredCache) Cleanup() {
+	c.l1.Cleanup()
+	c.l2.Cleanup()
+}
+
+func (c *TieredCache) StartCleanupWorker(ctx context.Context) {
+	go c.l1.StartCleanupWorker(ctx)
+	c.l2.StartCleanupWorker(ctx)
+}
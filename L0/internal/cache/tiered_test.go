@@ -0,0 +1,178 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"L0/internal/clock"
+	"L0/internal/mocks"
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestTieredCache(t *testing.T) (*TieredCache, *Cache, *clock.Fake) {
+	t.Helper()
+
+	l2, fake := newTestCache(t, DefaultOptions())
+
+	l1Opts := DefaultOptions()
+	l1Opts.TTL = time.Minute
+	l1Opts.CleanupInterval = 30 * time.Second
+	l1Opts.Clock = fake
+
+	c, err := NewTieredCache(l2, l1Opts)
+	require.NoError(t, err)
+
+	return c.(*TieredCache), l2, fake
+}
+
+func TestTieredCache_WriteThrough(t *testing.T) {
+	cache, l2, _ := newTestTieredCache(t)
+
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order"}))
+
+	assert.Equal(t, 1, cache.l1.Size())
+	assert.Equal(t, 1, l2.Size())
+
+	_, exists, _ := cache.Get(t.Context(), "order")
+	assert.True(t, exists)
+	assert.Equal(t, uint64(1), cache.l1.Stats().Hits)
+	assert.Equal(t, uint64(0), l2.Stats().Hits, "L1 hit must not reach L2")
+}
+
+func TestTieredCache_PromotesL2Hits(t *testing.T) {
+	cache, l2, _ := newTestTieredCache(t)
+
+	require.NoError(t, l2.Set(t.Context(), &models.Order{OrderUID: "order"}))
+
+	order, exists, _ := cache.Get(t.Context(), "order")
+	require.True(t, exists)
+	assert.Equal(t, "order", order.OrderUID)
+	assert.Equal(t, 1, cache.l1.Size())
+
+	_, exists, _ = cache.Get(t.Context(), "order")
+	assert.True(t, exists)
+	assert.Equal(t, uint64(1), l2.Stats().Hits)
+
+	stats := cache.Stats()
+	assert.Equal(t, uint64(2), stats.Hits)
+	assert.Equal(t, uint64(0), stats.Misses)
+}
+
+func TestTieredCache_L1ExpiresFirst(t *testing.T) {
+	cache, l2, fake := newTestTieredCache(t)
+
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "old"}))
+
+	// Another replica updates the shared tier.
+	require.NoError(t, l2.Set(t.Context(), &models.Order{OrderUID: "order", TrackNumber: "new"}))
+
+	order, _, _ := cache.Get(t.Context(), "order")
+	assert.Equal(t, "old", order.TrackNumber)
+
+	fake.Advance(time.Minute + time.Nanosecond)
+	order, exists, _ := cache.Get(t.Context(), "order")
+	require.True(t, exists)
+	assert.Equal(t, "new", order.TrackNumber)
+}
+
+func TestTieredCache_KeepsNewerL2Version(t *testing.T) {
+	cache, l2, _ := newTestTieredCache(t)
+
+	// Another replica already cached a newer version in the shared tier.
+	require.NoError(t, l2.Set(t.Context(), &models.Order{OrderUID: "order", Version: 2}))
+	require.NoError(t, cache.Set(t.Context(), &models.Order{OrderUID: "order", Version: 1}))
+
+	assert.Equal(t, 0, cache.l1.Size(), "L1 must not hold a version L2 dropped")
+	order, exists, _ := cache.Get(t.Context(), "order")
+	require.True(t, exists)
+	assert.Equal(t, int64(2), order.Version)
+}
+
+func TestTieredCache_UnversionedL2DropsL1Copy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l2 := mocks.NewMockCache(ctrl)
+	c, err := NewTieredCache(l2, DefaultOptions())
+	require.NoError(t, err)
+	cache := c.(*TieredCache)
+
+	require.NoError(t, cache.l1.Set(t.Context(), &models.Order{OrderUID: "order"}))
+	order := &models.Order{OrderUID: "order", Version: 1}
+	l2.EXPECT().Set(gomock.Any(), order).Return(nil)
+
+	require.NoError(t, cache.Set(t.Context(), order))
+	assert.Equal(t, 0, cache.l1.Size())
+}
+
+func TestTieredCache_Miss(t *testing.T) {
+	cache, _, _ := newTestTieredCache(t)
+
+	_, exists, _ := cache.Get(t.Context(), "missing")
+	assert.False(t, exists)
+	assert.Equal(t, 0, cache.l1.Size())
+	assert.Equal(t, uint64(1), cache.Stats().Misses)
+}
+
+func TestTieredCache_DeleteAndPurge(t *testing.T) {
+	cache, l2, _ := newTestTieredCache(t)
+
+	for _, uid := range []string{"order-1", "order-2", "order-3"} {
+		_ = cache.Set(t.Context(), &models.Order{OrderUID: uid})
+	}
+
+	assert.True(t, cache.Delete(t.Context(), "order-1"))
+	_, exists, _ := cache.Get(t.Context(), "order-1")
+	assert.False(t, exists)
+
+	assert.Equal(t, 1, cache.DeleteMany(t.Context(), []string{"order-2", "missing"}))
+	assert.Equal(t, 1, cache.Size())
+
+	assert.Equal(t, 1, cache.Purge(t.Context()))
+	assert.Equal(t, 0, cache.l1.Size())
+	assert.Equal(t, 0, l2.Size())
+}
+
+func TestTieredCache_ReadsListingsFromL2(t *testing.T) {
+	cache, l2, _ := newTestTieredCache(t)
+
+	_ = l2.Set(t.Context(), &models.Order{OrderUID: "order-1", TrackNumber: "track", CustomerID: "customer"})
+	_ = cache.Set(t.Context(), &models.Order{OrderUID: "order-2", TrackNumber: "track", CustomerID: "customer"})
+
+	assert.Len(t, mustGetAll(t, cache), 2)
+	assert.Equal(t, 2, cache.Size())
+
+	page, err := cache.List(t.Context(), "", 1)
+	require.NoError(t, err)
+	assert.Len(t, page.Orders, 1)
+}
+
+func TestTieredCache_L2WriteFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	l2 := mocks.NewMockCache(ctrl)
+	c, err := NewTieredCache(l2, DefaultOptions())
+	require.NoError(t, err)
+	cache := c.(*TieredCache)
+
+	order := &models.Order{OrderUID: "order"}
+	require.NoError(t, cache.l1.Set(t.Context(), order))
+	l2.EXPECT().Set(gomock.Any(), order).Return(errors.New("connection refused"))
+
+	assert.Error(t, cache.Set(t.Context(), order))
+	assert.Equal(t, 0, cache.l1.Size(), "L1 must not keep a copy L2 rejected")
+}
+
+func TestNewTieredCache_InvalidInput(t *testing.T) {
+	_, err := NewTieredCache(nil, DefaultOptions())
+	assert.Error(t, err)
+
+	_, err = NewTieredCache(NewCache(), Options{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,368 @@
+package cache
+
+import (
+	"L0/internal/clock"
+	"L0/internal/interfaces"
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+var ErrValueTooLarge = errors.New("value exceeds cache memory budget")
+
+type removalReason int
+
+const (
+	removedExpired removalReason = iota
+	// removedEvicted frees room for a new entry under MaxEntries.
+	removedEvicted
+	// removedOverBudget frees memory under MaxBytes.
+	removedOverBudget
+	// removedReplaced is reported before an overwrite stores the new value.
+	removedReplaced
+	// removedTooLarge drops the old copy when its replacement cannot fit.
+	removedTooLarge
+	removedDeleted
+	removedPurged
+)
+
+// TTLCache is an LRU cache with per-entry expiry, optional sliding
+// expiration and an optional byte budget. It is safe for concurrent use.
+type TTLCache[K comparable, V any] struct {
+	mu              sync.RWMutex
+	items           map[K]*list.Element
+	lru             *list.List
+	maxSize         int
+	maxBytes        int64
+	dafaultTTL      time.Duration
+	cleanupInterval time.Duration
+	expiration      ExpirationMode
+	maxLifetime     time.Duration
+	clock           clock.Clock
+	sizeOf          func(V) int64
+	bytes           int64
+	stats           interfaces.CacheStats
+
+	// onInsert and onRemove let wrapping caches keep derived state in sync.
+	// Both run with mu held. afterUnlock runs once mu has been released by
+	// any method that may have called them.
+	onInsert    func(e *entry[K, V], overwrite bool)
+	onRemove    func(e *entry[K, V], reason removalReason)
+	afterUnlock func()
+	// supersedes, when set, reports whether current is newer than next.
+	// Set then keeps current and drops next. Runs with mu held.
+	supersedes func(current, next V) bool
+}
+
+type entry[K comparable, V any] struct {
+	key        K
+	value      V
+	createdAt  time.Time
+	expiresAt  time.Time
+	lastAccess time.Time
+	size       int64
+}
+
+// NewTTLCache builds a cache from opts. sizeOf estimates the memory held by a
+// value; it may be nil unless opts.MaxBytes is set.
+func NewTTLCache[K comparable, V any](opts Options, sizeOf func(V) int64) (*TTLCache[K, V], error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid cache options: %w", err)
+	}
+	if opts.MaxBytes > 0 && sizeOf == nil {
+		return nil, fmt.Errorf("invalid cache options: byte budget requires a size function")
+	}
+	return newTTLCache[K, V](opts, sizeOf), nil
+}
+
+func newTTLCache[K comparable, V any](opts Options, sizeOf func(V) int64) *TTLCache[K, V] {
+	if opts.Clock == nil {
+		opts.Clock = clock.Real()
+	}
+
+	return &TTLCache[K, V]{
+		items:           make(map[K]*list.Element),
+		lru:             list.New(),
+		maxSize:         opts.MaxEntries,
+		maxBytes:        opts.MaxBytes,
+		dafaultTTL:      opts.TTL,
+		cleanupInterval: opts.CleanupInterval,
+		expiration:      opts.Expiration,
+		maxLifetime:     opts.MaxLifetime,
+		clock:           opts.Clock,
+		sizeOf:          sizeOf,
+	}
+}
+
+func (c *TTLCache[K, V]) Set(key K, value V) error {
+	_, err := c.set(key, value)
+	return err
+}
+
+// set is Set that also reports whether value was stored, rather than kept
+// out by a newer unexpired value under supersedes.
+func (c *TTLCache[K, V]) set(key K, value V) (bool, error) {
+	var size int64
+	if c.sizeOf != nil {
+		size = c.sizeOf(value)
+	}
+
+	if c.afterUnlock != nil {
+		defer c.afterUnlock()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if elem, exists := c.items[key]; exists && c.supersedes != nil {
+		e := elem.Value.(*entry[K, V])
+		if !now.After(e.expiresAt) && c.supersedes(e.value, value) {
+			return false, nil
+		}
+	}
+
+	if c.maxBytes > 0 && size > c.maxBytes {
+		// Drop any older copy so it is not served in place of the new one.
+		if elem, exists := c.items[key]; exists {
+			c.removeElement(elem, removedTooLarge)
+		}
+		return false, fmt.Errorf("value needs ~%d bytes, budget is %d: %w", size, c.maxBytes, ErrValueTooLarge)
+	}
+
+	if elem, exists := c.items[key]; exists {
+		e := elem.Value.(*entry[K, V])
+		if c.onRemove != nil {
+			c.onRemove(e, removedReplaced)
+		}
+		c.bytes += size - e.size
+		e.value = value
+		e.createdAt = now
+		e.expiresAt = now.Add(c.dafaultTTL)
+		e.lastAccess = now
+		e.size = size
+		c.lru.MoveToFront(elem)
+		if c.onInsert != nil {
+			c.onInsert(e, true)
+		}
+		c.enforceByteBudget()
+		return true, nil
+	}
+
+	for c.lru.Len() >= c.maxSize {
+		c.evictOldest(removedEvicted)
+	}
+
+	e := &entry[K, V]{
+		key:        key,
+		value:      value,
+		createdAt:  now,
+		expiresAt:  now.Add(c.dafaultTTL),
+		lastAccess: now,
+		size:       size,
+	}
+	c.items[key] = c.lru.PushFront(e)
+	c.bytes += size
+	if c.onInsert != nil {
+		c.onInsert(e, false)
+	}
+	c.enforceByteBudget()
+	return true, nil
+}
+
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	if c.afterUnlock != nil {
+		defer c.afterUnlock()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, exists := c.items[key]
+	if !exists {
+		c.stats.Misses++
+		return zero, false
+	}
+
+	now := c.clock.Now()
+	e := elem.Value.(*entry[K, V])
+	if now.After(e.expiresAt) {
+		c.removeElement(elem, removedExpired)
+		c.stats.Misses++
+		return zero, false
+	}
+
+	c.stats.Hits++
+	e.lastAccess = now
+	if c.expiration == ExpirationSliding {
+		e.expiresAt = now.Add(c.dafaultTTL)
+		if deadline := e.createdAt.Add(c.maxLifetime); e.expiresAt.After(deadline) {
+			e.expiresAt = deadline
+		}
+	}
+	c.lru.MoveToFront(elem)
+	return e.value, true
+}
+
+func (c *TTLCache[K, V]) Delete(key K) bool {
+	return c.DeleteMany([]K{key}) == 1
+}
+
+// DeleteMany removes the given keys and returns how many were present.
+func (c *TTLCache[K, V]) DeleteMany(keys []K) int {
+	if c.afterUnlock != nil {
+		defer c.afterUnlock()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	deleted := 0
+	for _, key := range keys {
+		if elem, exists := c.items[key]; exists {
+			c.removeElement(elem, removedDeleted)
+			deleted++
+		}
+	}
+	return deleted
+}
+
+// Purge empties the cache and returns how many entries it held. Counters
+// other than size and memory are kept.
+func (c *TTLCache[K, V]) Purge() int {
+	if c.afterUnlock != nil {
+		defer c.afterUnlock()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	purged := c.lru.Len()
+	for elem := c.lru.Back(); elem != nil; elem = c.lru.Back() {
+		c.removeElement(elem, removedPurged)
+	}
+	return purged
+}
+
+// Values returns every unexpired value in no particular order.
+func (c *TTLCache[K, V]) Values() []V {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	values := make([]V, 0, len(c.items))
+	now := c.clock.Now()
+
+	for _, elem := range c.items {
+		e := elem.Value.(*entry[K, V])
+		if now.After(e.expiresAt) {
+			continue
+		}
+		values = append(values, e.value)
+	}
+
+	return values
+}
+
+func (c *TTLCache[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.items)
+}
+
+func (c *TTLCache[K, V]) Stats() interfaces.CacheStats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	stats := c.stats
+	stats.Size = len(c.items)
+	stats.MemoryBytes = c.bytes
+	return stats
+}
+
+func (c *TTLCache[K, V]) Cleanup() {
+	if c.afterUnlock != nil {
+		defer c.afterUnlock()
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+
+	for _, elem := range c.items {
+		if now.After(elem.Value.(*entry[K, V]).expiresAt) {
+			c.removeElement(elem, removedExpired)
+		}
+	}
+}
+
+func (c *TTLCache[K, V]) StartCleanupWorker(ctx context.Context) {
+	ticker := time.NewTicker(c.cleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			c.Cleanup()
+		case <-ctx.Done():
+			log.Println("Cleanup worker stopped")
+			return
+		}
+	}
+}
+
+// peekLocked returns the value for key without touching recency or hit
+// counters, dropping it if it has expired. Caller must hold c.mu.
+func (c *TTLCache[K, V]) peekLocked(key K, now time.Time) (V, bool) {
+	var zero V
+	elem, exists := c.items[key]
+	if !exists {
+		return zero, false
+	}
+
+	e := elem.Value.(*entry[K, V])
+	if now.After(e.expiresAt) {
+		c.removeElement(elem, removedExpired)
+		return zero, false
+	}
+	return e.value, true
+}
+
+// evictOldest drops the least recently used entry. Caller must hold c.mu.
+func (c *TTLCache[K, V]) evictOldest(reason removalReason) {
+	elem := c.lru.Back()
+	if elem == nil {
+		return
+	}
+	c.removeElement(elem, reason)
+}
+
+// enforceByteBudget evicts least recently used entries until the cache fits
+// in maxBytes. The most recent entry is never evicted. Caller must hold c.mu.
+func (c *TTLCache[K, V]) enforceByteBudget() {
+	if c.maxBytes <= 0 {
+		return
+	}
+	for c.bytes > c.maxBytes && c.lru.Len() > 1 {
+		c.evictOldest(removedOverBudget)
+	}
+}
+
+// removeElement unlinks elem from both the map and the LRU list and updates
+// the counters for reason. Caller must hold c.mu.
+func (c *TTLCache[K, V]) removeElement(elem *list.Element, reason removalReason) {
+	e := c.lru.Remove(elem).(*entry[K, V])
+	delete(c.items, e.key)
+	c.bytes -= e.size
+
+	switch reason {
+	case removedExpired:
+		c.stats.Expirations++
+	case removedEvicted, removedOverBudget:
+		c.stats.Evictions++
+	}
+
+	if c.onRemove != nil {
+		c.onRemove(e, reason)
+	}
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"L0/internal/clock"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTTLCache(t *testing.T, opts Options, sizeOf func(string) int64) (*TTLCache[int, string], *clock.Fake) {
+	t.Helper()
+
+	fake := clock.NewFake(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	opts.Clock = fake
+
+	c, err := NewTTLCache[int](opts, sizeOf)
+	require.NoError(t, err)
+
+	return c, fake
+}
+
+func TestTTLCache_SetGetAndExpire(t *testing.T) {
+	c, clock := newTestTTLCache(t, DefaultOptions(), nil)
+
+	require.NoError(t, c.Set(1, "one"))
+	value, exists := c.Get(1)
+	require.True(t, exists)
+	assert.Equal(t, "one", value)
+
+	clock.Advance(defaultTTL + time.Nanosecond)
+	_, exists = c.Get(1)
+	assert.False(t, exists)
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.Hits)
+	assert.Equal(t, uint64(1), stats.Misses)
+	assert.Equal(t, uint64(1), stats.Expirations)
+	assert.Equal(t, 0, stats.Size)
+}
+
+func TestTTLCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxEntries = 2
+	c, _ := newTestTTLCache(t, opts, nil)
+
+	_ = c.Set(1, "one")
+	_ = c.Set(2, "two")
+	c.Get(1)
+	_ = c.Set(3, "three")
+
+	_, exists := c.Get(2)
+	assert.False(t, exists)
+	assert.ElementsMatch(t, []string{"one", "three"}, c.Values())
+	assert.Equal(t, uint64(1), c.Stats().Evictions)
+}
+
+func TestTTLCache_ByteBudget(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxBytes = 10
+	sizeOf := func(s string) int64 { return int64(len(s)) }
+	c, _ := newTestTTLCache(t, opts, sizeOf)
+
+	_ = c.Set(1, "aaaa")
+	_ = c.Set(2, "bbbb")
+	_ = c.Set(3, "cccc")
+
+	assert.Equal(t, 2, c.Len())
+	assert.Equal(t, int64(8), c.Stats().MemoryBytes)
+
+	err := c.Set(4, "this value is too long")
+	assert.ErrorIs(t, err, ErrValueTooLarge)
+}
+
+func TestNewTTLCache_ByteBudgetNeedsSizeFunc(t *testing.T) {
+	opts := DefaultOptions()
+	opts.MaxBytes = 1024
+
+	_, err := NewTTLCache[int, string](opts, nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,27 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	fake := NewFake(start)
+
+	assert.Equal(t, start, fake.Now())
+
+	fake.Advance(time.Minute)
+	assert.Equal(t, start.Add(time.Minute), fake.Now())
+
+	fake.Set(start)
+	assert.Equal(t, start, fake.Now())
+}
+
+func TestReal(t *testing.T) {
+	before := time.Now()
+	now := Real().Now()
+	assert.False(t, now.Before(before))
+}
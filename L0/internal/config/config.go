@@ -2,17 +2,55 @@ package config
 
 import (
 	"bufio"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
+
+	"L0/internal/cache"
+	"L0/internal/interfaces"
+	"L0/internal/models"
+	"L0/internal/service"
+)
+
+const (
+	CacheBackendMemory = "memory"
+	CacheBackendRedis  = "redis"
 )
 
 type Config struct {
 	DBPassword  string
 	HTTPPort    string
 	KafkaBroker string
-	HostName    string
-	IsKafka     bool
+	// KafkaConflictTopic receives orders rejected as conflicting versions of
+	// a stored order. Empty only logs them.
+	KafkaConflictTopic string
+	HostName           string
+	IsKafka            bool
+	// InstanceID identifies this replica in order change notifications.
+	InstanceID string
+	// OrderListener keeps the cache in sync with order writes made by
+	// other replicas.
+	OrderListener bool
+	CacheBackend  string
+	Redis         RedisConfig
+	Cache         cache.Options
+	Service       service.Options
+	WarmUp        interfaces.WarmUpOptions
+}
+
+type RedisConfig struct {
+	Addr      string
+	Password  string
+	DB        int
+	KeyPrefix string
+	// L1TTL enables a local cache in front of redis with this TTL. Zero
+	// disables it.
+	L1TTL time.Duration
 }
 
 func LoadConfig() *Config {
@@ -30,13 +68,207 @@ func LoadConfig() *Config {
 		}
 	}
 
+	orderListener := true
+	if value := env["ORDER_LISTENER_ENABLED"]; value != "" {
+		orderListener = parseBool("ORDER_LISTENER_ENABLED", value)
+	}
+
 	return &Config{
-		DBPassword:  env["DB_PASSWORD"],
-		HTTPPort:    env["HTTP_PORT"],
-		KafkaBroker: env["KAFKA_BROKERS"],
-		HostName:    hostName,
-		IsKafka:     isKafka,
+		DBPassword:         env["DB_PASSWORD"],
+		HTTPPort:           env["HTTP_PORT"],
+		KafkaBroker:        env["KAFKA_BROKERS"],
+		KafkaConflictTopic: env["KAFKA_CONFLICT_TOPIC"],
+		HostName:           hostName,
+		IsKafka:            isKafka,
+		InstanceID:         loadInstanceID(env),
+		OrderListener:      orderListener,
+		CacheBackend:       loadCacheBackend(env),
+		Redis:              loadRedisConfig(env),
+		Cache:              loadCacheOptions(env),
+		Service:            loadServiceOptions(env),
+		WarmUp:             loadWarmUpOptions(env),
+	}
+}
+
+func loadInstanceID(env map[string]string) string {
+	if id := env["INSTANCE_ID"]; id != "" {
+		return id
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		hostname = "app"
+	}
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		log.Fatalf("Failed to generate instance ID: %v", err)
+	}
+	return fmt.Sprintf("%s-%d-%s", hostname, os.Getpid(), hex.EncodeToString(suffix))
+}
+
+func loadCacheBackend(env map[string]string) string {
+	backend := strings.ToLower(env["CACHE_BACKEND"])
+	switch backend {
+	case "":
+		return CacheBackendMemory
+	case CacheBackendMemory, CacheBackendRedis:
+		return backend
+	default:
+		log.Fatalf("Invalid CACHE_BACKEND %q: want %s or %s", env["CACHE_BACKEND"], CacheBackendMemory, CacheBackendRedis)
+		return ""
+	}
+}
+
+func loadRedisConfig(env map[string]string) RedisConfig {
+	cfg := RedisConfig{
+		Addr:      env["REDIS_ADDR"],
+		Password:  env["REDIS_PASSWORD"],
+		KeyPrefix: cache.DefaultRedisKeyPrefix,
+	}
+
+	if cfg.Addr == "" {
+		cfg.Addr = "localhost:6379"
+	}
+
+	if value := env["REDIS_DB"]; value != "" {
+		cfg.DB = parseInt("REDIS_DB", value)
+	}
+
+	if value, ok := env["REDIS_KEY_PREFIX"]; ok {
+		if value == "" {
+			log.Fatal("Invalid REDIS_KEY_PREFIX: cannot be empty")
+		}
+		cfg.KeyPrefix = value
+	}
+
+	if value := env["CACHE_L1_TTL"]; value != "" {
+		cfg.L1TTL = parseDuration("CACHE_L1_TTL", value)
+	}
+
+	return cfg
+}
+
+func loadCacheOptions(env map[string]string) cache.Options {
+	opts := cache.DefaultOptions()
+
+	if value := env["CACHE_TTL"]; value != "" {
+		opts.TTL = parseDuration("CACHE_TTL", value)
+	}
+
+	if value := env["CACHE_MAX_ENTRIES"]; value != "" {
+		maxEntries, err := strconv.Atoi(value)
+		if err != nil {
+			log.Fatalf("Invalid CACHE_MAX_ENTRIES %q: %v", value, err)
+		}
+		opts.MaxEntries = maxEntries
+	}
+
+	if value := env["CACHE_MAX_BYTES"]; value != "" {
+		maxBytes, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			log.Fatalf("Invalid CACHE_MAX_BYTES %q: %v", value, err)
+		}
+		opts.MaxBytes = maxBytes
+	}
+
+	if value := env["CACHE_CLEANUP_INTERVAL"]; value != "" {
+		opts.CleanupInterval = parseDuration("CACHE_CLEANUP_INTERVAL", value)
+	}
+
+	if value := env["CACHE_EXPIRATION"]; value != "" {
+		mode, err := cache.ParseExpirationMode(value)
+		if err != nil {
+			log.Fatalf("Invalid CACHE_EXPIRATION: %v", err)
+		}
+		opts.Expiration = mode
+	}
+
+	if value := env["CACHE_MAX_LIFETIME"]; value != "" {
+		opts.MaxLifetime = parseDuration("CACHE_MAX_LIFETIME", value)
+	}
+
+	return opts
+}
+
+func loadServiceOptions(env map[string]string) service.Options {
+	opts := service.DefaultOptions()
+
+	if value := env["NEGATIVE_CACHE_TTL"]; value != "" {
+		opts.NegativeTTL = parseDuration("NEGATIVE_CACHE_TTL", value)
+	}
+
+	if value := env["ORDER_LOAD_TIMEOUT"]; value != "" {
+		opts.LoadTimeout = parseDuration("ORDER_LOAD_TIMEOUT", value)
+	}
+
+	if value := env["ORDER_FILTER_ENABLED"]; value != "" {
+		opts.MembershipFilter = parseBool("ORDER_FILTER_ENABLED", value)
+	}
+
+	if value := env["ORDER_CONFLICT_POLICY"]; value != "" {
+		policy, err := models.ParseConflictPolicy(value)
+		if err != nil {
+			log.Fatalf("Invalid ORDER_CONFLICT_POLICY: %v", err)
+		}
+		opts.ConflictPolicy = policy
+	}
+
+	if value := env["ITEM_STATUS_STRICT"]; value != "" {
+		opts.StrictItemStatus = parseBool("ITEM_STATUS_STRICT", value)
+	}
+
+	opts.SnapshotPath = env["CACHE_SNAPSHOT_PATH"]
+	if value := env["CACHE_SNAPSHOT_MAX_AGE"]; value != "" {
+		opts.SnapshotMaxAge = parseDuration("CACHE_SNAPSHOT_MAX_AGE", value)
+	}
+
+	return opts
+}
+
+func loadWarmUpOptions(env map[string]string) interfaces.WarmUpOptions {
+	opts := service.DefaultWarmUpOptions()
+
+	if value := env["WARMUP_LIMIT"]; value != "" {
+		opts.Limit = parseInt("WARMUP_LIMIT", value)
+	}
+
+	if value := env["WARMUP_MAX_AGE"]; value != "" {
+		opts.MaxAge = parseDuration("WARMUP_MAX_AGE", value)
+	}
+
+	if value := env["WARMUP_PARALLELISM"]; value != "" {
+		opts.Parallelism = parseInt("WARMUP_PARALLELISM", value)
+	}
+
+	if value := env["WARMUP_BACKGROUND"]; value != "" {
+		opts.Background = parseBool("WARMUP_BACKGROUND", value)
+	}
+
+	return opts
+}
+
+func parseInt(key, value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Fatalf("Invalid %s %q: %v", key, value, err)
+	}
+	return n
+}
+
+func parseBool(key, value string) bool {
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Fatalf("Invalid %s %q: %v", key, value, err)
+	}
+	return b
+}
+
+func parseDuration(key, value string) time.Duration {
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s %q: %v", key, value, err)
 	}
+	return d
 }
 
 func loadEnv() map[string]string {
@@ -0,0 +1,220 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"L0/internal/models"
+)
+
+// GetOrdersByUIDs loads the orders with the given UIDs using one query per
+// table. UIDs without an order are skipped; the result is in no particular
+// order.
+func (r *Database) GetOrdersByUIDs(ctx context.Context, orderUIDs []string) ([]*models.Order, error) {
+	if len(orderUIDs) == 0 {
+		return nil, nil
+	}
+
+	byUID, err := r.getOrdersMain(ctx, orderUIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(byUID) == 0 {
+		return nil, nil
+	}
+
+	uids := make([]string, 0, len(byUID))
+	for uid := range byUID {
+		uids = append(uids, uid)
+	}
+
+	if err := r.fillDeliveries(ctx, uids, byUID); err != nil {
+		return nil, err
+	}
+	if err := r.fillPayments(ctx, uids, byUID); err != nil {
+		return nil, err
+	}
+	if err := r.fillItems(ctx, uids, byUID); err != nil {
+		return nil, err
+	}
+	if err := r.fillStatusHistory(ctx, uids, byUID); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*models.Order, 0, len(byUID))
+	for _, order := range byUID {
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func (r *Database) getOrdersMain(ctx context.Context, orderUIDs []string) (map[string]*models.Order, error) {
+	query := `
+		SELECT order_uid, track_number, entry, locale, internal_signature,
+		       customer_id, delivery_service, shardkey, sm_id, date_created, oof_shard,
+		       version, status
+		FROM orders
+		WHERE order_uid = ANY($1)`
+
+	rows, err := r.Pool.Query(ctx, query, orderUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+	defer rows.Close()
+
+	byUID := make(map[string]*models.Order, len(orderUIDs))
+	for rows.Next() {
+		order := &models.Order{}
+		err := rows.Scan(
+			&order.OrderUID, &order.TrackNumber, &order.Entry, &order.Locale,
+			&order.InternalSignature, &order.CustomerID, &order.DeliveryService,
+			&order.Shardkey, &order.SmID, &order.DateCreated, &order.OofShard,
+			&order.Version, &order.Status,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		byUID[order.OrderUID] = order
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders: %w", err)
+	}
+	return byUID, nil
+}
+
+func (r *Database) fillDeliveries(ctx context.Context, orderUIDs []string, byUID map[string]*models.Order) error {
+	query := `
+		SELECT order_uid, name, phone, zip, city, address, region, email
+		FROM deliveries
+		WHERE order_uid = ANY($1)`
+
+	rows, err := r.Pool.Query(ctx, query, orderUIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	found := 0
+	for rows.Next() {
+		var orderUID string
+		var delivery models.Delivery
+		err := rows.Scan(
+			&orderUID, &delivery.Name, &delivery.Phone, &delivery.Zip, &delivery.City,
+			&delivery.Address, &delivery.Region, &delivery.Email,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		byUID[orderUID].Delivery = delivery
+		found++
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating deliveries: %w", err)
+	}
+	if found != len(orderUIDs) {
+		return fmt.Errorf("failed to get delivery: %d of %d orders have one", found, len(orderUIDs))
+	}
+	return nil
+}
+
+func (r *Database) fillPayments(ctx context.Context, orderUIDs []string, byUID map[string]*models.Order) error {
+	query := `
+		SELECT order_uid, transaction, request_id, currency, provider, amount, payment_dt,
+		       bank, delivery_cost, goods_total, custom_fee
+		FROM payments
+		WHERE order_uid = ANY($1)`
+
+	rows, err := r.Pool.Query(ctx, query, orderUIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get payments: %w", err)
+	}
+	defer rows.Close()
+
+	found := 0
+	for rows.Next() {
+		var orderUID string
+		var payment models.Payment
+		err := rows.Scan(
+			&orderUID, &payment.Transaction, &payment.RequestID, &payment.Currency, &payment.Provider,
+			&payment.Amount, &payment.PaymentDt, &payment.Bank, &payment.DeliveryCost,
+			&payment.GoodsTotal, &payment.CustomFee,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan payment: %w", err)
+		}
+		byUID[orderUID].Payment = payment
+		found++
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating payments: %w", err)
+	}
+	if found != len(orderUIDs) {
+		return fmt.Errorf("failed to get payment: %d of %d orders have one", found, len(orderUIDs))
+	}
+	return nil
+}
+
+func (r *Database) fillItems(ctx context.Context, orderUIDs []string, byUID map[string]*models.Order) error {
+	query := `
+		SELECT order_uid, chrt_id, track_number, price, rid, name, sale, size,
+		       total_price, nm_id, brand, status
+		FROM items
+		WHERE order_uid = ANY($1)`
+
+	rows, err := r.Pool.Query(ctx, query, orderUIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderUID string
+		var item models.Item
+		err := rows.Scan(
+			&orderUID, &item.ChrtID, &item.TrackNumber, &item.Price, &item.Rid, &item.Name,
+			&item.Sale, &item.Size, &item.TotalPrice, &item.NmID, &item.Brand, &item.Status,
+		)
+		if err != nil {
+			return fmt.Errorf("failed to scan item: %w", err)
+		}
+		order := byUID[orderUID]
+		order.Items = append(order.Items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating items: %w", err)
+	}
+	return nil
+}
+
+func (r *Database) fillStatusHistory(ctx context.Context, orderUIDs []string, byUID map[string]*models.Order) error {
+	query := `
+		SELECT order_uid, status, changed_at, source
+		FROM order_status_history
+		WHERE order_uid = ANY($1)
+		ORDER BY id`
+
+	rows, err := r.Pool.Query(ctx, query, orderUIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get status history: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderUID string
+		var change models.StatusChange
+		if err := rows.Scan(&orderUID, &change.Status, &change.ChangedAt, &change.Source); err != nil {
+			return fmt.Errorf("failed to scan status change: %w", err)
+		}
+		order := byUID[orderUID]
+		order.StatusHistory = append(order.StatusHistory, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating status history: %w", err)
+	}
+	return nil
+}
@@ -6,35 +6,42 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 var _ interfaces.Repository = (*Database)(nil)
 
+// Database is safe for concurrent use: every query takes its own connection
+// from Pool.
 type Database struct {
-	Conn *pgx.Conn
+	Pool *pgxpool.Pool
+	// instanceID tags change notifications so an instance can skip its own.
+	instanceID string
 }
 
-func NewDB(dbPassword, hostName string) (interfaces.Repository, error) {
-	connStr := fmt.Sprintf("postgres://L0User:%s@%s:5432/L0", dbPassword, hostName)
+func connString(dbPassword, hostName string) string {
+	return fmt.Sprintf("postgres://L0User:%s@%s:5432/L0", dbPassword, hostName)
+}
 
-	conn, err := pgx.Connect(context.Background(), connStr)
+func NewDB(dbPassword, hostName, instanceID string) (interfaces.Repository, error) {
+	pool, err := pgxpool.New(context.Background(), connString(dbPassword, hostName))
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("unable to create connection pool: %w", err)
 	}
 
-	err = conn.Ping(context.Background())
+	err = pool.Ping(context.Background())
 	if err != nil {
+		pool.Close()
 		return nil, fmt.Errorf("unable ping DB: %w", err)
 	}
 
 	log.Println("Connected to database")
-	return &Database{Conn: conn}, nil
+	return &Database{Pool: pool, instanceID: instanceID}, nil
 }
 
 func (db *Database) Close() {
-	if db.Conn != nil {
-		db.Conn.Close(context.Background())
+	if db.Pool != nil {
+		db.Pool.Close()
 		log.Println("Database disconnect")
 	}
 }
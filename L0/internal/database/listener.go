@@ -0,0 +1,137 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	listenerMinBackoff = 500 * time.Millisecond
+	listenerMaxBackoff = 30 * time.Second
+	// listenerChangeTimeout bounds one onChange call, so a stuck cache does
+	// not stall every notification behind it.
+	listenerChangeTimeout = 5 * time.Second
+)
+
+// NotificationConn is the part of *pgx.Conn the listener needs.
+type NotificationConn interface {
+	WaitForNotification(ctx context.Context) (*pgconn.Notification, error)
+	Close(ctx context.Context) error
+}
+
+// Dialer opens a connection that is already listening on OrderChangesChannel.
+type Dialer func(ctx context.Context) (NotificationConn, error)
+
+// NewDialer connects to the same database as NewDB on a dedicated connection,
+// since a listening connection cannot serve queries.
+func NewDialer(dbPassword, hostName string) Dialer {
+	return func(ctx context.Context) (NotificationConn, error) {
+		conn, err := pgx.Connect(ctx, connString(dbPassword, hostName))
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect: %w", err)
+		}
+
+		if _, err := conn.Exec(ctx, "LISTEN "+OrderChangesChannel); err != nil {
+			conn.Close(context.Background())
+			return nil, fmt.Errorf("failed to listen on %s: %w", OrderChangesChannel, err)
+		}
+		return conn, nil
+	}
+}
+
+// Listener passes order changes made by other instances to onChange. It
+// reconnects with exponential backoff when the connection drops. Changes
+// made while it is disconnected are not replayed; instead onReconnect runs
+// after every connection but the first, before any notification on it.
+type Listener struct {
+	dial          Dialer
+	instanceID    string
+	onChange      func(ctx context.Context, orderUID string)
+	onReconnect   func(ctx context.Context)
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+	changeTimeout time.Duration
+}
+
+func NewListener(dial Dialer, instanceID string, onChange func(ctx context.Context, orderUID string), onReconnect func(ctx context.Context)) *Listener {
+	return &Listener{
+		dial:          dial,
+		instanceID:    instanceID,
+		onChange:      onChange,
+		onReconnect:   onReconnect,
+		minBackoff:    listenerMinBackoff,
+		maxBackoff:    listenerMaxBackoff,
+		changeTimeout: listenerChangeTimeout,
+	}
+}
+
+// Run listens until ctx ends.
+func (l *Listener) Run(ctx context.Context) {
+	backoff := l.minBackoff
+	connected := false
+
+	for {
+		conn, err := l.dial(ctx)
+		if err == nil {
+			if connected {
+				log.Printf("Order change listener reconnected, changes may have been missed")
+				l.onReconnect(ctx)
+			} else {
+				log.Printf("Order change listener connected")
+			}
+			connected = true
+			err = l.consume(ctx, conn, &backoff)
+			conn.Close(context.Background())
+		}
+
+		if ctx.Err() != nil {
+			log.Println("Order change listener stopped")
+			return
+		}
+
+		log.Printf("Order change listener: %v; retrying in %s", err, backoff)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			log.Println("Order change listener stopped")
+			return
+		}
+		backoff = min(2*backoff, l.maxBackoff)
+	}
+}
+
+// consume handles notifications until the connection fails. Each delivered
+// notification proves the connection healthy and resets *backoff.
+func (l *Listener) consume(ctx context.Context, conn NotificationConn, backoff *time.Duration) error {
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return fmt.Errorf("connection lost: %w", err)
+		}
+		*backoff = l.minBackoff
+
+		l.handle(ctx, notification.Payload)
+	}
+}
+
+func (l *Listener) handle(ctx context.Context, payload string) {
+	var change OrderChange
+	if err := json.Unmarshal([]byte(payload), &change); err != nil || change.OrderUID == "" {
+		log.Printf("Order change listener: ignoring malformed payload %q", payload)
+		return
+	}
+
+	if change.Source == l.instanceID {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, l.changeTimeout)
+	defer cancel()
+	l.onChange(ctx, change.OrderUID)
+}
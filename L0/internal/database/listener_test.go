@@ -0,0 +1,190 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeConn struct {
+	notifications chan string
+	failures      chan error
+	closed        chan struct{}
+	closeOnce     sync.Once
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{
+		notifications: make(chan string, 10),
+		failures:      make(chan error, 1),
+		closed:        make(chan struct{}),
+	}
+}
+
+func (c *fakeConn) WaitForNotification(ctx context.Context) (*pgconn.Notification, error) {
+	select {
+	case payload := <-c.notifications:
+		return &pgconn.Notification{Channel: OrderChangesChannel, Payload: payload}, nil
+	case err := <-c.failures:
+		return nil, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (c *fakeConn) Close(context.Context) error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+// fakeNotifier hands out the queued connections in order, failing a dial
+// whenever the next queued connection is nil.
+type fakeNotifier struct {
+	mu    sync.Mutex
+	conns []*fakeConn
+	dials int
+}
+
+func (n *fakeNotifier) dial(context.Context) (NotificationConn, error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.dials++
+	if len(n.conns) == 0 {
+		return nil, errors.New("no more connections")
+	}
+	conn := n.conns[0]
+	n.conns = n.conns[1:]
+	if conn == nil {
+		return nil, errors.New("connection refused")
+	}
+	return conn, nil
+}
+
+func (n *fakeNotifier) dialCount() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.dials
+}
+
+// newTestListener reports each change as its order UID and each reconnect
+// as "reconnect" on the returned channel.
+func newTestListener(n *fakeNotifier) (*Listener, chan string) {
+	changes := make(chan string, 10)
+	listener := NewListener(n.dial, "self", func(_ context.Context, orderUID string) {
+		changes <- orderUID
+	}, func(context.Context) {
+		changes <- "reconnect"
+	})
+	listener.minBackoff = time.Millisecond
+	listener.maxBackoff = 4 * time.Millisecond
+	return listener, changes
+}
+
+func receive(t *testing.T, changes <-chan string) string {
+	t.Helper()
+	select {
+	case orderUID := <-changes:
+		return orderUID
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for order change")
+		return ""
+	}
+}
+
+func TestListener_AppliesChangesFromOtherInstances(t *testing.T) {
+	conn := newFakeConn()
+	listener, changes := newTestListener(&fakeNotifier{conns: []*fakeConn{conn}})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		listener.Run(ctx)
+		close(done)
+	}()
+
+	conn.notifications <- `{"order_uid":"own","source":"self"}`
+	conn.notifications <- `not json`
+	conn.notifications <- `{"order_uid":"order-1","source":"other"}`
+
+	assert.Equal(t, "order-1", receive(t, changes))
+
+	cancel()
+	<-done
+	<-conn.closed
+	assert.Empty(t, changes)
+}
+
+func TestListener_BoundsEachChange(t *testing.T) {
+	conn := newFakeConn()
+	deadlines := make(chan time.Duration, 1)
+	listener := NewListener((&fakeNotifier{conns: []*fakeConn{conn}}).dial, "self", func(ctx context.Context, _ string) {
+		deadline, _ := ctx.Deadline()
+		deadlines <- time.Until(deadline)
+		<-ctx.Done()
+	}, func(context.Context) {})
+	listener.changeTimeout = 10 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go listener.Run(ctx)
+
+	conn.notifications <- `{"order_uid":"order-1","source":"other"}`
+	conn.notifications <- `{"order_uid":"order-2","source":"other"}`
+
+	for range 2 {
+		select {
+		case remaining := <-deadlines:
+			assert.LessOrEqual(t, remaining, 10*time.Millisecond)
+		case <-time.After(time.Second):
+			require.Fail(t, "a stuck change blocked the next one")
+		}
+	}
+}
+
+func TestListener_Reconnects(t *testing.T) {
+	first, second := newFakeConn(), newFakeConn()
+	notifier := &fakeNotifier{conns: []*fakeConn{nil, first, nil, nil, second}}
+	listener, changes := newTestListener(notifier)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go listener.Run(ctx)
+
+	first.notifications <- `{"order_uid":"order-1","source":"other"}`
+	assert.Equal(t, "order-1", receive(t, changes))
+
+	first.failures <- errors.New("connection reset")
+	<-first.closed
+
+	// Only the reconnect resets, not the first connection after failed dials.
+	second.notifications <- `{"order_uid":"order-2","source":"other"}`
+	assert.Equal(t, "reconnect", receive(t, changes))
+	assert.Equal(t, "order-2", receive(t, changes))
+	assert.Equal(t, 5, notifier.dialCount())
+}
+
+func TestListener_StopsWhileWaitingToReconnect(t *testing.T) {
+	listener, _ := newTestListener(&fakeNotifier{})
+	listener.minBackoff = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		listener.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.Fail(t, "listener did not stop")
+	}
+}
@@ -0,0 +1,33 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OrderChangesChannel is the Postgres notification channel that carries an
+// OrderChange for every committed order write.
+const OrderChangesChannel = "order_changes"
+
+type OrderChange struct {
+	OrderUID string `json:"order_uid"`
+	// Source is the instance ID of the writer.
+	Source string `json:"source"`
+}
+
+// notifyOrderChanged queues a notification inside tx. Postgres delivers it
+// only if tx commits.
+func (r *Database) notifyOrderChanged(ctx context.Context, tx pgx.Tx, orderUID string) error {
+	payload, err := json.Marshal(OrderChange{OrderUID: orderUID, Source: r.instanceID})
+	if err != nil {
+		return fmt.Errorf("failed to encode order change: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, "SELECT pg_notify($1, $2)", OrderChangesChannel, string(payload)); err != nil {
+		return fmt.Errorf("failed to notify order change: %w", err)
+	}
+	return nil
+}
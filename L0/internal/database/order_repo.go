@@ -3,32 +3,36 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"L0/internal/models"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func NewOrderRepository(db *pgx.Conn) *Database {
-	return &Database{Conn: db}
+func NewOrderRepository(pool *pgxpool.Pool) *Database {
+	return &Database{Pool: pool}
 }
 
 func (r *Database) GetOrderByUID(ctx context.Context, orderUID string) (*models.Order, error) {
 	order := &models.Order{}
 	query := `
 		SELECT order_uid, track_number, entry, locale, internal_signature, 
-		       customer_id, delivery_service, shardkey, sm_id, date_created, oof_shard
+		       customer_id, delivery_service, shardkey, sm_id, date_created, oof_shard,
+		       version, status
 		FROM orders 
 		WHERE order_uid = $1`
 
-	err := r.Conn.QueryRow(ctx, query, orderUID).Scan(
+	err := r.Pool.QueryRow(ctx, query, orderUID).Scan(
 		&order.OrderUID, &order.TrackNumber, &order.Entry, &order.Locale,
 		&order.InternalSignature, &order.CustomerID, &order.DeliveryService,
 		&order.Shardkey, &order.SmID, &order.DateCreated, &order.OofShard,
+		&order.Version, &order.Status,
 	)
 	if err != nil {
 		if err == pgx.ErrNoRows {
-			return nil, fmt.Errorf("order not found")
+			return nil, models.ErrOrderNotFound
 		}
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
@@ -51,6 +55,12 @@ func (r *Database) GetOrderByUID(ctx context.Context, orderUID string) (*models.
 	}
 	order.Items = items
 
+	history, err := r.getStatusHistory(ctx, r.Pool, orderUID)
+	if err != nil {
+		return nil, err
+	}
+	order.StatusHistory = history
+
 	return order, nil
 }
 
@@ -61,7 +71,7 @@ func (r *Database) getDeliveryByOrderUID(ctx context.Context, orderUID string) (
 		FROM deliveries 
 		WHERE order_uid = $1`
 
-	err := r.Conn.QueryRow(ctx, query, orderUID).Scan(
+	err := r.Pool.QueryRow(ctx, query, orderUID).Scan(
 		&delivery.Name, &delivery.Phone, &delivery.Zip, &delivery.City,
 		&delivery.Address, &delivery.Region, &delivery.Email,
 	)
@@ -79,7 +89,7 @@ func (r *Database) getPaymentByOrderUID(ctx context.Context, orderUID string) (*
 		FROM payments 
 		WHERE order_uid = $1`
 
-	err := r.Conn.QueryRow(ctx, query, orderUID).Scan(
+	err := r.Pool.QueryRow(ctx, query, orderUID).Scan(
 		&payment.Transaction, &payment.RequestID, &payment.Currency, &payment.Provider,
 		&payment.Amount, &payment.PaymentDt, &payment.Bank, &payment.DeliveryCost,
 		&payment.GoodsTotal, &payment.CustomFee,
@@ -97,7 +107,7 @@ func (r *Database) getItemsByOrderUID(ctx context.Context, orderUID string) ([]m
         FROM items 
         WHERE order_uid = $1`
 
-	rows, err := r.Conn.Query(ctx, query, orderUID)
+	rows, err := r.Pool.Query(ctx, query, orderUID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get items: %w", err)
 	}
@@ -126,7 +136,62 @@ func (r *Database) getItemsByOrderUID(ctx context.Context, orderUID string) ([]m
 func (r *Database) GetAllOrderUIDs(ctx context.Context) ([]string, error) {
 	query := `SELECT order_uid FROM orders ORDER BY date_created DESC`
 
-	rows, err := r.Conn.Query(ctx, query)
+	return r.queryOrderUIDs(ctx, query)
+}
+
+// GetRecentOrderUIDs returns the newest order UIDs first. A zero limit or
+// since leaves that bound off.
+func (r *Database) GetRecentOrderUIDs(ctx context.Context, limit int, since time.Time) ([]string, error) {
+	query := `
+		SELECT order_uid FROM orders
+		WHERE date_created >= $1
+		ORDER BY date_created DESC
+		LIMIT $2`
+
+	// LIMIT NULL means no limit in Postgres.
+	var limitArg *int
+	if limit > 0 {
+		limitArg = &limit
+	}
+
+	return r.queryOrderUIDs(ctx, query, since, limitArg)
+}
+
+func (r *Database) GetOrderUIDsByTrackNumber(ctx context.Context, trackNumber string) ([]string, error) {
+	query := `
+		SELECT order_uid FROM orders
+		WHERE track_number = $1
+		ORDER BY date_created DESC`
+
+	return r.queryOrderUIDs(ctx, query, trackNumber)
+}
+
+func (r *Database) GetOrderUIDsByCustomerID(ctx context.Context, customerID string) ([]string, error) {
+	query := `
+		SELECT order_uid FROM orders
+		WHERE customer_id = $1
+		ORDER BY date_created DESC`
+
+	return r.queryOrderUIDs(ctx, query, customerID)
+}
+
+func (r *Database) GetOrdersSummary(ctx context.Context) (models.OrdersSummary, error) {
+	var summary models.OrdersSummary
+	var latest *time.Time
+	query := `SELECT COUNT(*), MAX(date_created), COALESCE(SUM(revision), 0)::bigint FROM orders`
+
+	if err := r.Pool.QueryRow(ctx, query).Scan(&summary.Count, &latest, &summary.RevisionSum); err != nil {
+		return models.OrdersSummary{}, fmt.Errorf("failed to get orders summary: %w", err)
+	}
+	if latest != nil {
+		summary.LatestDateCreated = *latest
+	}
+
+	return summary, nil
+}
+
+func (r *Database) queryOrderUIDs(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := r.Pool.Query(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order UIDs: %w", err)
 	}
@@ -141,5 +206,9 @@ func (r *Database) GetAllOrderUIDs(ctx context.Context) ([]string, error) {
 		orderUIDs = append(orderUIDs, uid)
 	}
 
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating order UIDs: %w", err)
+	}
+
 	return orderUIDs, nil
 }
@@ -10,50 +10,155 @@ import (
 	"github.com/jackc/pgx/v5"
 )
 
-func (r *Database) SaveOrder(ctx context.Context, order *models.Order) error {
-	tx, err := r.Conn.Begin(ctx)
+// maxSaveAttempts bounds how often SaveOrder re-reads an order that another
+// writer keeps changing between the read and the compare-and-swap.
+const maxSaveAttempts = 3
+
+// SaveOrder stores order unless its UID is already taken by an order of the
+// same or a newer version. A stored order with the same version and content
+// hash is left alone and reported as a duplicate; one with different
+// content is kept as a conflict, or replaced when policy is ConflictReplace.
+// When the order is written, its Status and StatusHistory are set to the
+// stored ones.
+func (r *Database) SaveOrder(ctx context.Context, order *models.Order, policy models.ConflictPolicy) (models.OrderOutcome, error) {
+	hash, err := order.ContentHash()
 	if err != nil {
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return 0, err
+	}
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	if err := r.saveOrderMain(ctx, tx, order); err != nil {
-		return err
+	outcome, err := r.saveOrderMain(ctx, tx, order, hash, policy)
+	if err != nil {
+		return 0, err
+	}
+	switch outcome {
+	case models.OutcomeDuplicate, models.OutcomeConflict, models.OutcomeStale:
+		log.Printf("Order not saved(SaveOrder): %s v%d is %s", order.OrderUID, order.Version, outcome)
+		return outcome, nil
 	}
 
 	if err := r.saveDelivery(ctx, tx, order); err != nil {
-		return err
+		return 0, err
 	}
 
 	if err := r.savePayment(ctx, tx, order); err != nil {
-		return err
+		return 0, err
 	}
 
 	if err := r.saveItems(ctx, tx, order); err != nil {
-		return err
+		return 0, err
+	}
+
+	// The status is owned by the service: a new order starts as created,
+	// and a rewritten one keeps the status it had.
+	if outcome == models.OutcomeCreated {
+		change, err := r.appendStatus(ctx, tx, order.OrderUID, models.StatusCreated, models.StatusSourceIngestion)
+		if err != nil {
+			return 0, err
+		}
+		order.Status = models.StatusCreated
+		order.StatusHistory = []models.StatusChange{change}
+	} else {
+		order.Status, order.StatusHistory, err = r.loadStatus(ctx, tx, order.OrderUID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := r.notifyOrderChanged(ctx, tx, order.OrderUID); err != nil {
+		return 0, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	log.Printf("Order saved to database(SaveOrder): %s", order.OrderUID)
-	return nil
+	log.Printf("Order saved to database(SaveOrder): %s v%d (%s)", order.OrderUID, order.Version, outcome)
+	return outcome, nil
 }
 
-func (r *Database) saveOrderMain(ctx context.Context, tx pgx.Tx, order *models.Order) error {
+// saveOrderMain inserts the order row, or classifies order against the
+// stored row and swaps it in if it wins. The swap only applies while the
+// stored version and hash are still the ones that were read.
+func (r *Database) saveOrderMain(ctx context.Context, tx pgx.Tx, order *models.Order, hash string, policy models.ConflictPolicy) (models.OrderOutcome, error) {
 	query := `
 		INSERT INTO orders (order_uid, track_number, entry, locale, internal_signature, 
-		                  customer_id, delivery_service, shardkey, sm_id, date_created, oof_shard)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		                  customer_id, delivery_service, shardkey, sm_id, date_created, oof_shard,
+		                  content_hash, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
 		ON CONFLICT (order_uid) DO NOTHING`
 
-	_, err := tx.Exec(ctx, query,
+	tag, err := tx.Exec(ctx, query,
 		order.OrderUID, order.TrackNumber, order.Entry, order.Locale,
 		order.InternalSignature, order.CustomerID, order.DeliveryService,
 		order.Shardkey, order.SmID, order.DateCreated, order.OofShard,
+		hash, order.Version,
 	)
-	return err
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert order: %w", err)
+	}
+	if tag.RowsAffected() == 1 {
+		return models.OutcomeCreated, nil
+	}
+
+	for attempt := 0; attempt < maxSaveAttempts; attempt++ {
+		var storedHash string
+		var storedVersion int64
+		err := tx.QueryRow(ctx, `SELECT content_hash, version FROM orders WHERE order_uid = $1`, order.OrderUID).
+			Scan(&storedHash, &storedVersion)
+		if err != nil {
+			return 0, fmt.Errorf("failed to read stored order version: %w", err)
+		}
+
+		outcome := classifyOrder(order.Version, hash, storedVersion, storedHash, policy)
+		if outcome != models.OutcomeUpdated && outcome != models.OutcomeReplaced {
+			return outcome, nil
+		}
+
+		query = `
+			UPDATE orders SET
+				track_number = $2, entry = $3, locale = $4, internal_signature = $5,
+				customer_id = $6, delivery_service = $7, shardkey = $8, sm_id = $9,
+				date_created = $10, oof_shard = $11, content_hash = $12, version = $13
+			WHERE order_uid = $1 AND version = $14 AND content_hash = $15`
+
+		tag, err := tx.Exec(ctx, query,
+			order.OrderUID, order.TrackNumber, order.Entry, order.Locale,
+			order.InternalSignature, order.CustomerID, order.DeliveryService,
+			order.Shardkey, order.SmID, order.DateCreated, order.OofShard,
+			hash, order.Version, storedVersion, storedHash,
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update order: %w", err)
+		}
+		if tag.RowsAffected() == 1 {
+			return outcome, nil
+		}
+	}
+
+	return 0, fmt.Errorf("order %s changed concurrently %d times while saving", order.OrderUID, maxSaveAttempts)
+}
+
+// classifyOrder compares an incoming order with the stored row for its UID.
+func classifyOrder(version int64, hash string, storedVersion int64, storedHash string, policy models.ConflictPolicy) models.OrderOutcome {
+	switch {
+	case version < storedVersion:
+		return models.OutcomeStale
+	case version > storedVersion:
+		return models.OutcomeUpdated
+	case hash == storedHash:
+		return models.OutcomeDuplicate
+	// Rows saved before content hashes existed have no hash to compare
+	// with; they are rewritten as before, which also records their hash.
+	case storedHash == "" || policy == models.ConflictReplace:
+		return models.OutcomeReplaced
+	default:
+		return models.OutcomeConflict
+	}
 }
 
 func (r *Database) saveDelivery(ctx context.Context, tx pgx.Tx, order *models.Order) error {
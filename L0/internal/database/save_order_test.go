@@ -0,0 +1,36 @@
+package database
+
+import (
+	"testing"
+
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyOrder(t *testing.T) {
+	tests := []struct {
+		name          string
+		version       int64
+		hash          string
+		storedVersion int64
+		storedHash    string
+		policy        models.ConflictPolicy
+		want          models.OrderOutcome
+	}{
+		{"older version", 1, "b", 2, "a", models.ConflictReplace, models.OutcomeStale},
+		{"older version with same content", 1, "a", 2, "a", models.ConflictReject, models.OutcomeStale},
+		{"newer version", 3, "b", 2, "a", models.ConflictReject, models.OutcomeUpdated},
+		{"same version and content", 2, "a", 2, "a", models.ConflictReject, models.OutcomeDuplicate},
+		{"same version, different content, reject", 2, "b", 2, "a", models.ConflictReject, models.OutcomeConflict},
+		{"same version, different content, replace", 2, "b", 2, "a", models.ConflictReplace, models.OutcomeReplaced},
+		{"row without a hash", 1, "b", 1, "", models.ConflictReject, models.OutcomeReplaced},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyOrder(tt.version, tt.hash, tt.storedVersion, tt.storedHash, tt.policy)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
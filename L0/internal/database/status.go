@@ -0,0 +1,112 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"L0/internal/models"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// querier is satisfied by both *pgxpool.Pool and pgx.Tx.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// UpdateOrderStatus moves an order from status from to status to and
+// appends the change to its history. It fails with models.ErrStatusChanged
+// if the order is no longer in status from.
+func (r *Database) UpdateOrderStatus(ctx context.Context, orderUID string, from, to models.OrderStatus, source string) (models.StatusChange, error) {
+	tx, err := r.Pool.Begin(ctx)
+	if err != nil {
+		return models.StatusChange{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `UPDATE orders SET status = $3 WHERE order_uid = $1 AND status = $2`, orderUID, from, to)
+	if err != nil {
+		return models.StatusChange{}, fmt.Errorf("failed to update order status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		var exists bool
+		if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM orders WHERE order_uid = $1)`, orderUID).Scan(&exists); err != nil {
+			return models.StatusChange{}, fmt.Errorf("failed to check order: %w", err)
+		}
+		if !exists {
+			return models.StatusChange{}, models.ErrOrderNotFound
+		}
+		return models.StatusChange{}, models.ErrStatusChanged
+	}
+
+	change, err := r.appendStatus(ctx, tx, orderUID, to, source)
+	if err != nil {
+		return models.StatusChange{}, err
+	}
+
+	if err := r.notifyOrderChanged(ctx, tx, orderUID); err != nil {
+		return models.StatusChange{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return models.StatusChange{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return change, nil
+}
+
+func (r *Database) appendStatus(ctx context.Context, tx pgx.Tx, orderUID string, status models.OrderStatus, source string) (models.StatusChange, error) {
+	change := models.StatusChange{Status: status, Source: source}
+	query := `
+		INSERT INTO order_status_history (order_uid, status, source)
+		VALUES ($1, $2, $3)
+		RETURNING changed_at`
+
+	if err := tx.QueryRow(ctx, query, orderUID, status, source).Scan(&change.ChangedAt); err != nil {
+		return models.StatusChange{}, fmt.Errorf("failed to record order status: %w", err)
+	}
+	return change, nil
+}
+
+// loadStatus reads the current status of an order and its history.
+func (r *Database) loadStatus(ctx context.Context, q querier, orderUID string) (models.OrderStatus, []models.StatusChange, error) {
+	var status models.OrderStatus
+	if err := q.QueryRow(ctx, `SELECT status FROM orders WHERE order_uid = $1`, orderUID).Scan(&status); err != nil {
+		return "", nil, fmt.Errorf("failed to get order status: %w", err)
+	}
+
+	history, err := r.getStatusHistory(ctx, q, orderUID)
+	if err != nil {
+		return "", nil, err
+	}
+	return status, history, nil
+}
+
+func (r *Database) getStatusHistory(ctx context.Context, q querier, orderUID string) ([]models.StatusChange, error) {
+	query := `
+		SELECT status, changed_at, source
+		FROM order_status_history
+		WHERE order_uid = $1
+		ORDER BY id`
+
+	rows, err := q.Query(ctx, query, orderUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get status history: %w", err)
+	}
+	defer rows.Close()
+
+	var history []models.StatusChange
+	for rows.Next() {
+		var change models.StatusChange
+		if err := rows.Scan(&change.Status, &change.ChangedAt, &change.Source); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		history = append(history, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating status history: %w", err)
+	}
+
+	return history, nil
+}
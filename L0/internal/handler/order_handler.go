@@ -6,9 +6,12 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"L0/internal/interfaces"
+	"L0/internal/itemstatus"
+	"L0/internal/models"
 )
 
 type OrderHandler struct {
@@ -17,7 +20,9 @@ type OrderHandler struct {
 }
 
 func NewOrderHandler(orderService interfaces.OrderService) (*OrderHandler, error) {
-	tmpl, err := template.ParseFiles(
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"itemStatus": orderService.LookupItemStatus,
+	}).ParseFiles(
 		"html/index.html",
 		"html/order.html",
 	)
@@ -37,16 +42,39 @@ func (h *OrderHandler) ShowHomePage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	orders := h.orderService.GetAllOrders()
-	orderUIDs := make([]string, 0, len(orders))
-	for _, order := range orders {
+	query := r.URL.Query()
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			http.Error(w, "Invalid limit", http.StatusBadRequest)
+			return
+		}
+		limit = parsed
+	}
+
+	page, err := h.orderService.ListOrders(r.Context(), query.Get("cursor"), limit)
+	if err != nil {
+		http.Error(w, "Invalid cursor", http.StatusBadRequest)
+		log.Printf("Error listing orders: %v", err)
+		return
+	}
+
+	orderUIDs := make([]string, 0, len(page.Orders))
+	for _, order := range page.Orders {
 		orderUIDs = append(orderUIDs, order.OrderUID)
 	}
 
 	data := struct {
-		OrderUIDs []string
+		OrderUIDs  []string
+		NextCursor string
+		PrevCursor string
+		Limit      int
 	}{
-		OrderUIDs: orderUIDs,
+		OrderUIDs:  orderUIDs,
+		NextCursor: page.NextCursor,
+		PrevCursor: page.PrevCursor,
+		Limit:      limit,
 	}
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -95,8 +123,88 @@ func (h *OrderHandler) GetOrderJSON(w http.ResponseWriter, r *http.Request) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if err := json.NewEncoder(w).Encode(order); err != nil {
+	if err := json.NewEncoder(w).Encode(h.orderResponse(order)); err != nil {
 		http.Error(w, `{"error": "Failed to encode order"}`, http.StatusInternalServerError)
 		log.Printf("Error encoding order to JSON: %v", err)
 	}
 }
+
+func (h *OrderHandler) SearchOrdersJSON(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	trackNumber := query.Get("track_number")
+	customerID := query.Get("customer_id")
+
+	ctx := r.Context()
+	var (
+		orders []*models.Order
+		err    error
+	)
+	switch {
+	case trackNumber != "":
+		orders, err = h.orderService.GetOrdersByTrackNumber(ctx, trackNumber)
+	case customerID != "":
+		orders, err = h.orderService.GetOrdersByCustomerID(ctx, customerID)
+	default:
+		http.Error(w, `{"error": "track_number or customer_id is required"}`, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, `{"error": "Failed to search orders"}`, http.StatusInternalServerError)
+		log.Printf("Error searching orders: %v", err)
+		return
+	}
+
+	response := make([]orderResponse, 0, len(orders))
+	for _, order := range orders {
+		response = append(response, h.orderResponse(order))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		http.Error(w, `{"error": "Failed to encode orders"}`, http.StatusInternalServerError)
+		log.Printf("Error encoding orders to JSON: %v", err)
+	}
+}
+
+func (h *OrderHandler) GetCacheStatsJSON(w http.ResponseWriter, r *http.Request) {
+	stats := h.orderService.CacheStats()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(stats); err != nil {
+		http.Error(w, `{"error": "Failed to encode cache stats"}`, http.StatusInternalServerError)
+		log.Printf("Error encoding cache stats to JSON: %v", err)
+	}
+}
+
+func (h *OrderHandler) GetItemStatusesJSON(w http.ResponseWriter, r *http.Request) {
+	statuses := h.orderService.ItemStatuses()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		http.Error(w, `{"error": "Failed to encode item statuses"}`, http.StatusInternalServerError)
+		log.Printf("Error encoding item statuses to JSON: %v", err)
+	}
+}
+
+// orderResponse is an order as served by the JSON API, with each item's
+// status described next to its code.
+type orderResponse struct {
+	*models.Order
+	Items []itemResponse `json:"items"`
+}
+
+type itemResponse struct {
+	models.Item
+	StatusInfo itemstatus.Status `json:"status_info"`
+}
+
+func (h *OrderHandler) orderResponse(order *models.Order) orderResponse {
+	items := make([]itemResponse, 0, len(order.Items))
+	for _, item := range order.Items {
+		items = append(items, itemResponse{
+			Item:       item,
+			StatusInfo: h.orderService.LookupItemStatus(item.Status),
+		})
+	}
+	return orderResponse{Order: order, Items: items}
+}
@@ -5,12 +5,17 @@ import (
 	"html/template"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"L0/internal/interfaces"
+	"L0/internal/itemstatus"
 	"L0/internal/mocks"
 	"L0/internal/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -29,6 +34,8 @@ func createTestTemplates() *template.Template {
 {{else}}
     <p>Заказы не найдены</p>
 {{end}}
+{{if .PrevCursor}}<a href="/?cursor={{.PrevCursor}}">prev</a>{{end}}
+{{if .NextCursor}}<a href="/?cursor={{.NextCursor}}">next</a>{{end}}
 </body>
 </html>{{end}}`)
 
@@ -60,7 +67,7 @@ func TestOrderHandler_ShowHomePage(t *testing.T) {
 			{OrderUID: "order-1"},
 			{OrderUID: "order-2"},
 		}
-		mockService.EXPECT().GetAllOrders().Return(orders)
+		mockService.EXPECT().ListOrders(gomock.Any(), "", 0).Return(interfaces.OrderPage{Orders: orders}, nil)
 
 		req := httptest.NewRequest("GET", "/", nil)
 		rr := httptest.NewRecorder()
@@ -74,7 +81,7 @@ func TestOrderHandler_ShowHomePage(t *testing.T) {
 	})
 
 	t.Run("no orders", func(t *testing.T) {
-		mockService.EXPECT().GetAllOrders().Return([]*models.Order{})
+		mockService.EXPECT().ListOrders(gomock.Any(), "", 0).Return(interfaces.OrderPage{}, nil)
 
 		req := httptest.NewRequest("GET", "/", nil)
 		rr := httptest.NewRecorder()
@@ -85,6 +92,45 @@ func TestOrderHandler_ShowHomePage(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "Заказы не найдены")
 	})
 
+	t.Run("pagination links", func(t *testing.T) {
+		page := interfaces.OrderPage{
+			Orders:     []*models.Order{{OrderUID: "order-3"}},
+			NextCursor: "next-token",
+			PrevCursor: "prev-token",
+		}
+		mockService.EXPECT().ListOrders(gomock.Any(), "some-cursor", 1).Return(page, nil)
+
+		req := httptest.NewRequest("GET", "/?cursor=some-cursor&limit=1", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ShowHomePage(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "order-3")
+		assert.Contains(t, rr.Body.String(), "cursor=next-token")
+		assert.Contains(t, rr.Body.String(), "cursor=prev-token")
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockService.EXPECT().ListOrders(gomock.Any(), "bad", 0).Return(interfaces.OrderPage{}, errors.New("invalid cursor"))
+
+		req := httptest.NewRequest("GET", "/?cursor=bad", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ShowHomePage(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/?limit=abc", nil)
+		rr := httptest.NewRecorder()
+
+		handler.ShowHomePage(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
 	t.Run("not found for other paths", func(t *testing.T) {
 		req := httptest.NewRequest("GET", "/other", nil)
 		rr := httptest.NewRecorder()
@@ -159,6 +205,80 @@ func TestOrderHandler_ShowOrder(t *testing.T) {
 	})
 }
 
+func TestOrderHandler_ShowOrderStatusTimeline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+	handler := &OrderHandler{orderService: mockService, tmpl: parseOrderPage(t, mockService)}
+
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	order := &models.Order{
+		OrderUID:    "test-123",
+		DateCreated: created,
+		Status:      models.StatusPaid,
+		StatusHistory: []models.StatusChange{
+			{Status: models.StatusCreated, ChangedAt: created, Source: models.StatusSourceIngestion},
+			{Status: models.StatusPaid, ChangedAt: created.Add(time.Hour), Source: "billing"},
+		},
+	}
+	mockService.EXPECT().GetOrder(gomock.Any(), "test-123").Return(order, nil)
+
+	req := httptest.NewRequest("GET", "/order/test-123", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ShowOrder(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	assert.Contains(t, body, "2025-01-01 12:00:00")
+	assert.Contains(t, body, "2025-01-01 13:00:00")
+	assert.Contains(t, body, "(billing)")
+	assert.Less(t, strings.Index(body, "(ingestion)"), strings.Index(body, "(billing)"), "timeline must be oldest first")
+}
+
+func parseOrderPage(t *testing.T, orderService *mocks.MockOrderService) *template.Template {
+	t.Helper()
+
+	tmpl, err := template.New("").Funcs(template.FuncMap{
+		"itemStatus": orderService.LookupItemStatus,
+	}).ParseFiles("../../html/order.html")
+	require.NoError(t, err)
+	return tmpl
+}
+
+func TestOrderHandler_ShowOrderItemStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+	handler := &OrderHandler{orderService: mockService, tmpl: parseOrderPage(t, mockService)}
+
+	order := &models.Order{
+		OrderUID: "test-123",
+		Items: []models.Item{
+			{Name: "Known", Status: 202},
+			{Name: "Unknown", Status: 999},
+		},
+	}
+	mockService.EXPECT().GetOrder(gomock.Any(), "test-123").Return(order, nil)
+	mockService.EXPECT().LookupItemStatus(gomock.Any()).DoAndReturn(itemstatus.Default().Lookup).Times(2)
+
+	req := httptest.NewRequest("GET", "/order/test-123", nil)
+	rr := httptest.NewRecorder()
+
+	handler.ShowOrder(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+	known := itemstatus.Default().Lookup(202)
+	assert.Contains(t, body, known.Label.RU)
+	assert.Contains(t, body, known.Category.Label.RU+" · 202")
+	assert.Contains(t, body, `title="`+known.Label.EN)
+	assert.Contains(t, body, "item-status-unknown")
+	assert.Contains(t, body, "Неизвестный статус 999")
+}
+
 func TestOrderHandler_GetOrderJSON(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -175,8 +295,20 @@ func TestOrderHandler_GetOrderJSON(t *testing.T) {
 			OrderUID:    "test-123",
 			TrackNumber: "TRACK-001",
 			Entry:       "WBIL",
+			Version:     3,
+			Status:      models.StatusShipped,
+			StatusHistory: []models.StatusChange{
+				{Status: models.StatusShipped, ChangedAt: time.Date(2025, 1, 2, 9, 0, 0, 0, time.UTC), Source: "warehouse"},
+			},
+			Items: []models.Item{{Name: "Item", Status: 202}},
 		}
 		mockService.EXPECT().GetOrder(gomock.Any(), "test-123").Return(order, nil)
+		mockService.EXPECT().LookupItemStatus(202).Return(itemstatus.Status{
+			Code:     202,
+			Label:    itemstatus.Label{RU: "Собран", EN: "Picked"},
+			Category: itemstatus.Category{Key: "processing", Label: itemstatus.Label{RU: "На складе", EN: "At the warehouse"}},
+			Known:    true,
+		})
 
 		req := httptest.NewRequest("GET", "/api/order/test-123", nil)
 		rr := httptest.NewRecorder()
@@ -187,6 +319,11 @@ func TestOrderHandler_GetOrderJSON(t *testing.T) {
 		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
 		assert.Contains(t, rr.Body.String(), `"order_uid":"test-123"`)
 		assert.Contains(t, rr.Body.String(), `"track_number":"TRACK-001"`)
+		assert.Contains(t, rr.Body.String(), `"version":3`)
+		assert.Contains(t, rr.Body.String(), `"status":"shipped"`)
+		assert.Contains(t, rr.Body.String(), `"status_history":[{"status":"shipped","changed_at":"2025-01-02T09:00:00Z","source":"warehouse"}]`)
+		assert.Contains(t, rr.Body.String(), `"name":"Item"`)
+		assert.Contains(t, rr.Body.String(), `"status":202,"status_info":{"code":202,"label":{"ru":"Собран","en":"Picked"},"category":{"key":"processing","label":{"ru":"На складе","en":"At the warehouse"}},"known":true}`)
 	})
 
 	t.Run("order not found JSON", func(t *testing.T) {
@@ -224,6 +361,65 @@ func TestOrderHandler_GetOrderJSON(t *testing.T) {
 	})
 }
 
+func TestOrderHandler_SearchOrdersJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+
+	handler := &OrderHandler{
+		orderService: mockService,
+		tmpl:         createTestTemplates(),
+	}
+
+	t.Run("by track number", func(t *testing.T) {
+		orders := []*models.Order{{OrderUID: "order-1", TrackNumber: "WB1"}}
+		mockService.EXPECT().GetOrdersByTrackNumber(gomock.Any(), "WB1").Return(orders, nil)
+
+		req := httptest.NewRequest("GET", "/api/orders?track_number=WB1", nil)
+		rr := httptest.NewRecorder()
+
+		handler.SearchOrdersJSON(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Body.String(), `"order_uid":"order-1"`)
+	})
+
+	t.Run("by customer", func(t *testing.T) {
+		mockService.EXPECT().GetOrdersByCustomerID(gomock.Any(), "alice").Return(nil, nil)
+
+		req := httptest.NewRequest("GET", "/api/orders?customer_id=alice", nil)
+		rr := httptest.NewRecorder()
+
+		handler.SearchOrdersJSON(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "[]\n", rr.Body.String())
+	})
+
+	t.Run("missing parameters", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/api/orders", nil)
+		rr := httptest.NewRecorder()
+
+		handler.SearchOrdersJSON(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "error")
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService.EXPECT().GetOrdersByTrackNumber(gomock.Any(), "WB1").Return(nil, errors.New("db error"))
+
+		req := httptest.NewRequest("GET", "/api/orders?track_number=WB1", nil)
+		rr := httptest.NewRecorder()
+
+		handler.SearchOrdersJSON(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
+
 func TestNewOrderHandler(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -246,3 +442,54 @@ func TestNewOrderHandler(t *testing.T) {
 		assert.NotNil(t, handler.orderService)
 	})
 }
+
+func TestOrderHandler_GetItemStatusesJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+	handler := &OrderHandler{orderService: mockService, tmpl: createTestTemplates()}
+
+	mockService.EXPECT().ItemStatuses().Return([]itemstatus.Status{{
+		Code:     100,
+		Label:    itemstatus.Label{RU: "Ожидает оплаты", EN: "Awaiting payment"},
+		Category: itemstatus.Category{Key: "pending", Label: itemstatus.Label{RU: "Ожидает обработки", EN: "Pending"}},
+		Known:    true,
+	}})
+
+	req := httptest.NewRequest("GET", "/api/item-statuses", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetItemStatusesJSON(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"code":100`)
+	assert.Contains(t, rr.Body.String(), `"en":"Awaiting payment"`)
+	assert.Contains(t, rr.Body.String(), `"key":"pending"`)
+}
+
+func TestOrderHandler_GetCacheStatsJSON(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockService := mocks.NewMockOrderService(ctrl)
+
+	handler := &OrderHandler{
+		orderService: mockService,
+		tmpl:         createTestTemplates(),
+	}
+
+	mockService.EXPECT().CacheStats().Return(interfaces.CacheStats{Hits: 7, Misses: 2, Size: 3})
+
+	req := httptest.NewRequest("GET", "/api/cache/stats", nil)
+	rr := httptest.NewRecorder()
+
+	handler.GetCacheStatsJSON(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	assert.Contains(t, rr.Body.String(), `"hits":7`)
+	assert.Contains(t, rr.Body.String(), `"misses":2`)
+	assert.Contains(t, rr.Body.String(), `"size":3`)
+}
@@ -8,10 +8,36 @@ import (
 //go:generate mockgen -source=cache.go -destination=../mocks/mock_cache.go -package=mocks
 
 type Cache interface {
-	Set(order *models.Order) error
-	Get(orderUID string) (*models.Order, bool)
-	GetAll() []*models.Order
+	// Set never replaces a cached order with an older Version; such a
+	// write is dropped without an error.
+	Set(ctx context.Context, order *models.Order) error
+	// Get reports a miss as (nil, false, nil); err is set only when the
+	// cache itself failed.
+	Get(ctx context.Context, orderUID string) (*models.Order, bool, error)
+	Delete(ctx context.Context, orderUID string) bool
+	DeleteMany(ctx context.Context, orderUIDs []string) int
+	Purge(ctx context.Context) int
+	GetAll(ctx context.Context) ([]*models.Order, error)
+	List(ctx context.Context, cursor string, limit int) (OrderPage, error)
 	Size() int
+	Stats() CacheStats
 	Cleanup()
 	StartCleanupWorker(ctx context.Context)
 }
+
+// OrderPage is one page of orders sorted by DateCreated, newest first.
+// Empty cursors mean there is no page in that direction.
+type OrderPage struct {
+	Orders     []*models.Order `json:"orders"`
+	NextCursor string          `json:"next_cursor,omitempty"`
+	PrevCursor string          `json:"prev_cursor,omitempty"`
+}
+
+type CacheStats struct {
+	Hits        uint64 `json:"hits"`
+	Misses      uint64 `json:"misses"`
+	Expirations uint64 `json:"expirations"`
+	Evictions   uint64 `json:"evictions"`
+	Size        int    `json:"size"`
+	MemoryBytes int64  `json:"memory_bytes"`
+}
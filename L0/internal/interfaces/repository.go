@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
 	"L0/internal/models"
 )
@@ -9,8 +10,14 @@ import (
 //go:generate mockgen -source=repository.go -destination=../mocks/mock_repository.go -package=mocks
 
 type Repository interface {
-	SaveOrder(ctx context.Context, order *models.Order) error
+	SaveOrder(ctx context.Context, order *models.Order, policy models.ConflictPolicy) (models.OrderOutcome, error)
 	GetOrderByUID(ctx context.Context, orderUID string) (*models.Order, error)
+	GetOrdersByUIDs(ctx context.Context, orderUIDs []string) ([]*models.Order, error)
 	GetAllOrderUIDs(ctx context.Context) ([]string, error)
+	GetRecentOrderUIDs(ctx context.Context, limit int, since time.Time) ([]string, error)
+	GetOrderUIDsByTrackNumber(ctx context.Context, trackNumber string) ([]string, error)
+	GetOrderUIDsByCustomerID(ctx context.Context, customerID string) ([]string, error)
+	GetOrdersSummary(ctx context.Context) (models.OrdersSummary, error)
+	UpdateOrderStatus(ctx context.Context, orderUID string, from, to models.OrderStatus, source string) (models.StatusChange, error)
 	Close()
 }
@@ -2,15 +2,71 @@ package interfaces
 
 import (
 	"context"
+	"time"
 
+	"L0/internal/itemstatus"
 	"L0/internal/models"
 )
 
 //go:generate mockgen -source=service.go -destination=../mocks/mock_service.go -package=mocks
 
 type OrderService interface {
-	ProcessOrder(ctx context.Context, order *models.Order) error
+	// ProcessOrder saves order and reports whether it was new, a duplicate
+	// of the stored order, or a conflicting version of it.
+	ProcessOrder(ctx context.Context, order *models.Order) (models.OrderOutcome, error)
 	GetOrder(ctx context.Context, orderUID string) (*models.Order, error)
-	GetAllOrders() []*models.Order
-	RestoreCacheFromDB(ctx context.Context) error
+	GetOrdersByTrackNumber(ctx context.Context, trackNumber string) ([]*models.Order, error)
+	GetOrdersByCustomerID(ctx context.Context, customerID string) ([]*models.Order, error)
+	ListOrders(ctx context.Context, cursor string, limit int) (OrderPage, error)
+	RestoreCache(ctx context.Context, opts WarmUpOptions) (WarmUp, error)
+	RestoreCacheFromDB(ctx context.Context, opts WarmUpOptions) (WarmUp, error)
+	SaveCacheSnapshot(ctx context.Context) error
+	CacheStats() CacheStats
+	InvalidateOrder(ctx context.Context, orderUID string) bool
+	// ApplyOrderChange drops the cached copy of an order another instance
+	// wrote and records that the order exists.
+	ApplyOrderChange(ctx context.Context, orderUID string)
+	InvalidateOrders(ctx context.Context, orderUIDs []string) int
+	PurgeCache(ctx context.Context) int
+	// ResyncCache drops every cached answer after order changes may have
+	// been missed, e.g. while the change listener was reconnecting.
+	ResyncCache(ctx context.Context)
+	// ChangeOrderStatus moves an order to status, recording source in its
+	// history, and returns the updated order. Transitions the lifecycle
+	// does not allow fail with models.ErrInvalidStatusTransition.
+	ChangeOrderStatus(ctx context.Context, orderUID string, status models.OrderStatus, source string) (*models.Order, error)
+	// ItemStatuses returns the item status catalog ordered by code.
+	ItemStatuses() []itemstatus.Status
+	// LookupItemStatus describes an item status code, with Known false for
+	// codes missing from the catalog.
+	LookupItemStatus(code int) itemstatus.Status
+}
+
+// WarmUpOptions bound how much of the database is loaded into the cache on
+// startup. Zero Limit or MaxAge means no bound.
+type WarmUpOptions struct {
+	// Limit loads at most this many of the newest orders.
+	Limit int
+	// MaxAge skips orders created longer ago than this.
+	MaxAge      time.Duration
+	Parallelism int
+	// Background returns as soon as the orders to load are known and fills
+	// the cache while the caller carries on.
+	Background bool
+}
+
+type WarmUpProgress struct {
+	Total   int           `json:"total"`
+	Loaded  int           `json:"loaded"`
+	Failed  int           `json:"failed"`
+	Done    bool          `json:"done"`
+	Elapsed time.Duration `json:"elapsed"`
+}
+
+// WarmUp tracks a cache warm-up that may still be running.
+type WarmUp interface {
+	Progress() WarmUpProgress
+	// Wait blocks until the warm-up finishes or ctx ends and returns the
+	// progress at that point.
+	Wait(ctx context.Context) WarmUpProgress
 }
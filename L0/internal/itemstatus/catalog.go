@@ -0,0 +1,159 @@
+package itemstatus
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// CategoryUnknown is the category of codes missing from the catalog.
+const CategoryUnknown = "unknown"
+
+//go:embed catalog.json
+var catalogJSON []byte
+
+// Label is a text in Russian and English.
+type Label struct {
+	RU string `json:"ru"`
+	EN string `json:"en"`
+}
+
+type Category struct {
+	Key   string `json:"key"`
+	Label Label  `json:"label"`
+}
+
+// Status describes an item status code. Known is false for codes missing
+// from the catalog.
+type Status struct {
+	Code     int      `json:"code"`
+	Label    Label    `json:"label"`
+	Category Category `json:"category"`
+	Known    bool     `json:"known"`
+}
+
+// Catalog maps item status codes to their labels and categories. It is
+// read-only and safe for concurrent use.
+type Catalog struct {
+	statuses map[int]Status
+	codes    []int
+}
+
+var loadDefault = sync.OnceValues(func() (*Catalog, error) {
+	return Parse(catalogJSON)
+})
+
+// Default returns the catalog embedded in the binary.
+func Default() *Catalog {
+	catalog, err := loadDefault()
+	if err != nil {
+		panic(fmt.Sprintf("embedded item status catalog: %v", err))
+	}
+	return catalog
+}
+
+// Parse reads a catalog in the format of catalog.json. Every status must
+// have a unique code, a known category and labels in both languages.
+func Parse(data []byte) (*Catalog, error) {
+	var raw struct {
+		Categories []Category `json:"categories"`
+		Statuses   []struct {
+			Code     int    `json:"code"`
+			Category string `json:"category"`
+			Label    Label  `json:"label"`
+		} `json:"statuses"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode item status catalog: %w", err)
+	}
+
+	categories := make(map[string]Category, len(raw.Categories))
+	for _, category := range raw.Categories {
+		if category.Key == "" || category.Key == CategoryUnknown {
+			return nil, fmt.Errorf("invalid category key %q", category.Key)
+		}
+		if _, exists := categories[category.Key]; exists {
+			return nil, fmt.Errorf("duplicate category %q", category.Key)
+		}
+		if err := category.Label.validate(); err != nil {
+			return nil, fmt.Errorf("category %q: %w", category.Key, err)
+		}
+		categories[category.Key] = category
+	}
+
+	c := &Catalog{statuses: make(map[int]Status, len(raw.Statuses))}
+	for _, status := range raw.Statuses {
+		if status.Code < 0 {
+			return nil, fmt.Errorf("status code cannot be negative, got %d", status.Code)
+		}
+		if _, exists := c.statuses[status.Code]; exists {
+			return nil, fmt.Errorf("duplicate status code %d", status.Code)
+		}
+		category, ok := categories[status.Category]
+		if !ok {
+			return nil, fmt.Errorf("status %d: unknown category %q", status.Code, status.Category)
+		}
+		if err := status.Label.validate(); err != nil {
+			return nil, fmt.Errorf("status %d: %w", status.Code, err)
+		}
+		c.statuses[status.Code] = Status{
+			Code:     status.Code,
+			Label:    status.Label,
+			Category: category,
+			Known:    true,
+		}
+		c.codes = append(c.codes, status.Code)
+	}
+	slices.Sort(c.codes)
+
+	return c, nil
+}
+
+func (l Label) validate() error {
+	if l.RU == "" || l.EN == "" {
+		return fmt.Errorf("label needs both ru and en, got %q and %q", l.RU, l.EN)
+	}
+	return nil
+}
+
+// Known reports whether code is in the catalog.
+func (c *Catalog) Known(code int) bool {
+	_, ok := c.statuses[code]
+	return ok
+}
+
+// Lookup returns the status for code. Codes missing from the catalog get a
+// generic "unknown" status with Known set to false, so they can still be
+// shown.
+func (c *Catalog) Lookup(code int) Status {
+	if status, ok := c.statuses[code]; ok {
+		return status
+	}
+	return Status{
+		Code: code,
+		Label: Label{
+			RU: fmt.Sprintf("Неизвестный статус %d", code),
+			EN: fmt.Sprintf("Unknown status %d", code),
+		},
+		Category: Category{
+			Key:   CategoryUnknown,
+			Label: Label{RU: "Неизвестно", EN: "Unknown"},
+		},
+	}
+}
+
+// Codes returns the known codes in ascending order.
+func (c *Catalog) Codes() []int {
+	return slices.Clone(c.codes)
+}
+
+// Statuses returns the known statuses ordered by code.
+func (c *Catalog) Statuses() []Status {
+	statuses := make([]Status, 0, len(c.codes))
+	for _, code := range c.codes {
+		statuses = append(statuses, c.statuses[code])
+	}
+	return statuses
+}
@@ -0,0 +1,99 @@
+package itemstatus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	catalog := Default()
+
+	require.NotEmpty(t, catalog.Codes())
+	assert.IsIncreasing(t, catalog.Codes())
+
+	status := catalog.Lookup(202)
+	assert.True(t, status.Known)
+	assert.Equal(t, 202, status.Code)
+	assert.NotEmpty(t, status.Label.RU)
+	assert.NotEmpty(t, status.Label.EN)
+	assert.Equal(t, "processing", status.Category.Key)
+}
+
+func TestCatalog_Lookup_Unknown(t *testing.T) {
+	catalog := Default()
+
+	assert.False(t, catalog.Known(999))
+
+	status := catalog.Lookup(999)
+	assert.False(t, status.Known)
+	assert.Equal(t, 999, status.Code)
+	assert.Equal(t, CategoryUnknown, status.Category.Key)
+	assert.Equal(t, "Unknown status 999", status.Label.EN)
+	assert.Equal(t, "Неизвестный статус 999", status.Label.RU)
+}
+
+func TestCatalog_Statuses(t *testing.T) {
+	catalog, err := Parse([]byte(`{
+		"categories": [{"key": "done", "label": {"ru": "Готово", "en": "Done"}}],
+		"statuses": [
+			{"code": 20, "category": "done", "label": {"ru": "Б", "en": "B"}},
+			{"code": 10, "category": "done", "label": {"ru": "А", "en": "A"}}
+		]
+	}`))
+	require.NoError(t, err)
+
+	statuses := catalog.Statuses()
+	require.Len(t, statuses, 2)
+	assert.Equal(t, 10, statuses[0].Code)
+	assert.Equal(t, "A", statuses[0].Label.EN)
+	assert.Equal(t, "Done", statuses[0].Category.Label.EN)
+	assert.Equal(t, 20, statuses[1].Code)
+	assert.Equal(t, []int{10, 20}, catalog.Codes())
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "malformed JSON",
+			data: `{`,
+		},
+		{
+			name: "duplicate code",
+			data: `{"categories": [{"key": "a", "label": {"ru": "а", "en": "a"}}],
+				"statuses": [
+					{"code": 1, "category": "a", "label": {"ru": "а", "en": "a"}},
+					{"code": 1, "category": "a", "label": {"ru": "б", "en": "b"}}
+				]}`,
+		},
+		{
+			name: "unknown category",
+			data: `{"statuses": [{"code": 1, "category": "a", "label": {"ru": "а", "en": "a"}}]}`,
+		},
+		{
+			name: "missing english label",
+			data: `{"categories": [{"key": "a", "label": {"ru": "а", "en": "a"}}],
+				"statuses": [{"code": 1, "category": "a", "label": {"ru": "а"}}]}`,
+		},
+		{
+			name: "reserved category key",
+			data: `{"categories": [{"key": "unknown", "label": {"ru": "а", "en": "a"}}]}`,
+		},
+		{
+			name: "negative code",
+			data: `{"categories": [{"key": "a", "label": {"ru": "а", "en": "a"}}],
+				"statuses": [{"code": -1, "category": "a", "label": {"ru": "а", "en": "a"}}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.data))
+			assert.Error(t, err)
+		})
+	}
+}
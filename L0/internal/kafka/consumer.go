@@ -3,6 +3,7 @@ package kafka
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log"
 	"strings"
 
@@ -15,9 +16,14 @@ import (
 type Consumer struct {
 	reader       *kafka.Reader
 	orderService interfaces.OrderService
+	// conflicts receives messages rejected as conflicting versions of a
+	// stored order. Nil means they are only logged.
+	conflicts *kafka.Writer
 }
 
-func NewConsumer(orderService interfaces.OrderService, brokers string) *Consumer {
+// NewConsumer reads orders from the orders topic. A non-empty conflictTopic
+// routes rejected conflicting orders there.
+func NewConsumer(orderService interfaces.OrderService, brokers string, conflictTopic string) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  strings.Split(brokers, ","),
 		Topic:    "orders",
@@ -26,9 +32,19 @@ func NewConsumer(orderService interfaces.OrderService, brokers string) *Consumer
 		MaxBytes: 10e6, // 10MB
 	})
 
+	var conflicts *kafka.Writer
+	if conflictTopic != "" {
+		conflicts = &kafka.Writer{
+			Addr:     kafka.TCP(strings.Split(brokers, ",")...),
+			Topic:    conflictTopic,
+			Balancer: &kafka.LeastBytes{},
+		}
+	}
+
 	return &Consumer{
 		reader:       reader,
 		orderService: orderService,
+		conflicts:    conflicts,
 	}
 }
 
@@ -50,14 +66,45 @@ func (c *Consumer) Start(ctx context.Context) {
 			continue
 		}
 
-		if err := c.orderService.ProcessOrder(ctx, &order); err != nil {
+		outcome, err := c.orderService.ProcessOrder(ctx, &order)
+		switch {
+		case errors.Is(err, models.ErrOrderConflict):
+			c.routeConflict(ctx, msg, order.OrderUID, err)
+		case errors.Is(err, models.ErrStaleVersion):
+			log.Printf("Stale order version skipped (Consumer): %v", err)
+		case err != nil:
 			log.Printf("Failed to process order %s: %v", order.OrderUID, err)
-		} else {
+		case outcome == models.OutcomeDuplicate:
+			log.Printf("Duplicate order skipped (Consumer): %s", order.OrderUID)
+		default:
 			log.Printf("Order processed success (Consumer): %s", order.OrderUID)
 		}
 	}
 }
 
+func (c *Consumer) routeConflict(ctx context.Context, msg kafka.Message, orderUID string, cause error) {
+	if c.conflicts == nil {
+		log.Printf("Conflicting order rejected (Consumer): %s: %v", orderUID, cause)
+		return
+	}
+
+	err := c.conflicts.WriteMessages(ctx, kafka.Message{
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: []kafka.Header{{Key: "error", Value: []byte(cause.Error())}},
+	})
+	if err != nil {
+		log.Printf("Failed to route conflicting order %s to %s: %v", orderUID, c.conflicts.Topic, err)
+		return
+	}
+	log.Printf("Conflicting order routed (Consumer): %s -> %s", orderUID, c.conflicts.Topic)
+}
+
 func (c *Consumer) Close() error {
+	if c.conflicts != nil {
+		if err := c.conflicts.Close(); err != nil {
+			log.Printf("Failed to close conflict writer: %v", err)
+		}
+	}
 	return c.reader.Close()
 }
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"L0/internal/interfaces"
+	"L0/internal/itemstatus"
 	"L0/internal/models"
 	"context"
 	"log"
@@ -70,7 +71,7 @@ func generateFakeItems(count int, trackNum string) []models.Item {
 			TotalPrice:  gofakeit.Number(100, 5000),
 			NmID:        gofakeit.Int64(),
 			Brand:       gofakeit.Company(),
-			Status:      gofakeit.Number(100, 400),
+			Status:      gofakeit.RandomInt(itemstatus.Default().Codes()),
 		}
 	}
 
@@ -103,7 +104,7 @@ func LocalOrderGeneration(ctx context.Context, orderService interfaces.OrderServ
 func generateAndProcessOrder(ctx context.Context, orderService interfaces.OrderService) error {
 	order := GenerateTestOrder()
 
-	if err := orderService.ProcessOrder(ctx, order); err != nil {
+	if _, err := orderService.ProcessOrder(ctx, order); err != nil {
 		return err
 	}
 
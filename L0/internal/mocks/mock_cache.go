@@ -10,6 +10,7 @@
 package mocks
 
 import (
+	interfaces "L0/internal/interfaces"
 	models "L0/internal/models"
 	context "context"
 	reflect "reflect"
@@ -53,47 +54,106 @@ func (mr *MockCacheMockRecorder) Cleanup() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cleanup", reflect.TypeOf((*MockCache)(nil).Cleanup))
 }
 
+// Delete mocks base method.
+func (m *MockCache) Delete(ctx context.Context, orderUID string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, orderUID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheMockRecorder) Delete(ctx, orderUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCache)(nil).Delete), ctx, orderUID)
+}
+
+// DeleteMany mocks base method.
+func (m *MockCache) DeleteMany(ctx context.Context, orderUIDs []string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMany", ctx, orderUIDs)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// DeleteMany indicates an expected call of DeleteMany.
+func (mr *MockCacheMockRecorder) DeleteMany(ctx, orderUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMany", reflect.TypeOf((*MockCache)(nil).DeleteMany), ctx, orderUIDs)
+}
+
 // Get mocks base method.
-func (m *MockCache) Get(orderUID string) (*models.Order, bool) {
+func (m *MockCache) Get(ctx context.Context, orderUID string) (*models.Order, bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", orderUID)
+	ret := m.ctrl.Call(m, "Get", ctx, orderUID)
 	ret0, _ := ret[0].(*models.Order)
 	ret1, _ := ret[1].(bool)
-	return ret0, ret1
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockCacheMockRecorder) Get(orderUID any) *gomock.Call {
+func (mr *MockCacheMockRecorder) Get(ctx, orderUID any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), orderUID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache)(nil).Get), ctx, orderUID)
 }
 
 // GetAll mocks base method.
-func (m *MockCache) GetAll() []*models.Order {
+func (m *MockCache) GetAll(ctx context.Context) ([]*models.Order, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll")
+	ret := m.ctrl.Call(m, "GetAll", ctx)
 	ret0, _ := ret[0].([]*models.Order)
-	return ret0
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockCacheMockRecorder) GetAll() *gomock.Call {
+func (mr *MockCacheMockRecorder) GetAll(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCache)(nil).GetAll), ctx)
+}
+
+// List mocks base method.
+func (m *MockCache) List(ctx context.Context, cursor string, limit int) (interfaces.OrderPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, cursor, limit)
+	ret0, _ := ret[0].(interfaces.OrderPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockCacheMockRecorder) List(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCache)(nil).List), ctx, cursor, limit)
+}
+
+// Purge mocks base method.
+func (m *MockCache) Purge(ctx context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Purge", ctx)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// Purge indicates an expected call of Purge.
+func (mr *MockCacheMockRecorder) Purge(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCache)(nil).GetAll))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Purge", reflect.TypeOf((*MockCache)(nil).Purge), ctx)
 }
 
 // Set mocks base method.
-func (m *MockCache) Set(order *models.Order) error {
+func (m *MockCache) Set(ctx context.Context, order *models.Order) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Set", order)
+	ret := m.ctrl.Call(m, "Set", ctx, order)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Set indicates an expected call of Set.
-func (mr *MockCacheMockRecorder) Set(order any) *gomock.Call {
+func (mr *MockCacheMockRecorder) Set(ctx, order any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache)(nil).Set), ctx, order)
 }
 
 // Size mocks base method.
@@ -121,3 +181,17 @@ func (mr *MockCacheMockRecorder) StartCleanupWorker(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartCleanupWorker", reflect.TypeOf((*MockCache)(nil).StartCleanupWorker), ctx)
 }
+
+// Stats mocks base method.
+func (m *MockCache) Stats() interfaces.CacheStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(interfaces.CacheStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCache)(nil).Stats))
+}
@@ -13,6 +13,7 @@ import (
 	models "L0/internal/models"
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -83,16 +84,107 @@ func (mr *MockRepositoryMockRecorder) GetOrderByUID(ctx, orderUID any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderByUID", reflect.TypeOf((*MockRepository)(nil).GetOrderByUID), ctx, orderUID)
 }
 
+// GetOrderUIDsByCustomerID mocks base method.
+func (m *MockRepository) GetOrderUIDsByCustomerID(ctx context.Context, customerID string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderUIDsByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderUIDsByCustomerID indicates an expected call of GetOrderUIDsByCustomerID.
+func (mr *MockRepositoryMockRecorder) GetOrderUIDsByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderUIDsByCustomerID", reflect.TypeOf((*MockRepository)(nil).GetOrderUIDsByCustomerID), ctx, customerID)
+}
+
+// GetOrderUIDsByTrackNumber mocks base method.
+func (m *MockRepository) GetOrderUIDsByTrackNumber(ctx context.Context, trackNumber string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrderUIDsByTrackNumber", ctx, trackNumber)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrderUIDsByTrackNumber indicates an expected call of GetOrderUIDsByTrackNumber.
+func (mr *MockRepositoryMockRecorder) GetOrderUIDsByTrackNumber(ctx, trackNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrderUIDsByTrackNumber", reflect.TypeOf((*MockRepository)(nil).GetOrderUIDsByTrackNumber), ctx, trackNumber)
+}
+
+// GetOrdersByUIDs mocks base method.
+func (m *MockRepository) GetOrdersByUIDs(ctx context.Context, orderUIDs []string) ([]*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByUIDs", ctx, orderUIDs)
+	ret0, _ := ret[0].([]*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByUIDs indicates an expected call of GetOrdersByUIDs.
+func (mr *MockRepositoryMockRecorder) GetOrdersByUIDs(ctx, orderUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByUIDs", reflect.TypeOf((*MockRepository)(nil).GetOrdersByUIDs), ctx, orderUIDs)
+}
+
+// GetOrdersSummary mocks base method.
+func (m *MockRepository) GetOrdersSummary(ctx context.Context) (models.OrdersSummary, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersSummary", ctx)
+	ret0, _ := ret[0].(models.OrdersSummary)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersSummary indicates an expected call of GetOrdersSummary.
+func (mr *MockRepositoryMockRecorder) GetOrdersSummary(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersSummary", reflect.TypeOf((*MockRepository)(nil).GetOrdersSummary), ctx)
+}
+
+// GetRecentOrderUIDs mocks base method.
+func (m *MockRepository) GetRecentOrderUIDs(ctx context.Context, limit int, since time.Time) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRecentOrderUIDs", ctx, limit, since)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRecentOrderUIDs indicates an expected call of GetRecentOrderUIDs.
+func (mr *MockRepositoryMockRecorder) GetRecentOrderUIDs(ctx, limit, since any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentOrderUIDs", reflect.TypeOf((*MockRepository)(nil).GetRecentOrderUIDs), ctx, limit, since)
+}
+
 // SaveOrder mocks base method.
-func (m *MockRepository) SaveOrder(ctx context.Context, order *models.Order) error {
+func (m *MockRepository) SaveOrder(ctx context.Context, order *models.Order, policy models.ConflictPolicy) (models.OrderOutcome, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SaveOrder", ctx, order)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret := m.ctrl.Call(m, "SaveOrder", ctx, order, policy)
+	ret0, _ := ret[0].(models.OrderOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SaveOrder indicates an expected call of SaveOrder.
-func (mr *MockRepositoryMockRecorder) SaveOrder(ctx, order any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) SaveOrder(ctx, order, policy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockRepository)(nil).SaveOrder), ctx, order, policy)
+}
+
+// UpdateOrderStatus mocks base method.
+func (m *MockRepository) UpdateOrderStatus(ctx context.Context, orderUID string, from, to models.OrderStatus, source string) (models.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOrderStatus", ctx, orderUID, from, to, source)
+	ret0, _ := ret[0].(models.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateOrderStatus indicates an expected call of UpdateOrderStatus.
+func (mr *MockRepositoryMockRecorder) UpdateOrderStatus(ctx, orderUID, from, to, source any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveOrder", reflect.TypeOf((*MockRepository)(nil).SaveOrder), ctx, order)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOrderStatus", reflect.TypeOf((*MockRepository)(nil).UpdateOrderStatus), ctx, orderUID, from, to, source)
 }
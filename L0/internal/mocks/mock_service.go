@@ -10,6 +10,8 @@
 package mocks
 
 import (
+	interfaces "L0/internal/interfaces"
+	itemstatus "L0/internal/itemstatus"
 	models "L0/internal/models"
 	context "context"
 	reflect "reflect"
@@ -41,18 +43,45 @@ func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
 	return m.recorder
 }
 
-// GetAllOrders mocks base method.
-func (m *MockOrderService) GetAllOrders() []*models.Order {
+// ApplyOrderChange mocks base method.
+func (m *MockOrderService) ApplyOrderChange(ctx context.Context, orderUID string) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllOrders")
-	ret0, _ := ret[0].([]*models.Order)
+	m.ctrl.Call(m, "ApplyOrderChange", ctx, orderUID)
+}
+
+// ApplyOrderChange indicates an expected call of ApplyOrderChange.
+func (mr *MockOrderServiceMockRecorder) ApplyOrderChange(ctx, orderUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyOrderChange", reflect.TypeOf((*MockOrderService)(nil).ApplyOrderChange), ctx, orderUID)
+}
+
+// CacheStats mocks base method.
+func (m *MockOrderService) CacheStats() interfaces.CacheStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CacheStats")
+	ret0, _ := ret[0].(interfaces.CacheStats)
 	return ret0
 }
 
-// GetAllOrders indicates an expected call of GetAllOrders.
-func (mr *MockOrderServiceMockRecorder) GetAllOrders() *gomock.Call {
+// CacheStats indicates an expected call of CacheStats.
+func (mr *MockOrderServiceMockRecorder) CacheStats() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllOrders", reflect.TypeOf((*MockOrderService)(nil).GetAllOrders))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CacheStats", reflect.TypeOf((*MockOrderService)(nil).CacheStats))
+}
+
+// ChangeOrderStatus mocks base method.
+func (m *MockOrderService) ChangeOrderStatus(ctx context.Context, orderUID string, status models.OrderStatus, source string) (*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeOrderStatus", ctx, orderUID, status, source)
+	ret0, _ := ret[0].(*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangeOrderStatus indicates an expected call of ChangeOrderStatus.
+func (mr *MockOrderServiceMockRecorder) ChangeOrderStatus(ctx, orderUID, status, source any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeOrderStatus", reflect.TypeOf((*MockOrderService)(nil).ChangeOrderStatus), ctx, orderUID, status, source)
 }
 
 // GetOrder mocks base method.
@@ -70,12 +99,114 @@ func (mr *MockOrderServiceMockRecorder) GetOrder(ctx, orderUID any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderService)(nil).GetOrder), ctx, orderUID)
 }
 
+// GetOrdersByCustomerID mocks base method.
+func (m *MockOrderService) GetOrdersByCustomerID(ctx context.Context, customerID string) ([]*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByCustomerID", ctx, customerID)
+	ret0, _ := ret[0].([]*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByCustomerID indicates an expected call of GetOrdersByCustomerID.
+func (mr *MockOrderServiceMockRecorder) GetOrdersByCustomerID(ctx, customerID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByCustomerID", reflect.TypeOf((*MockOrderService)(nil).GetOrdersByCustomerID), ctx, customerID)
+}
+
+// GetOrdersByTrackNumber mocks base method.
+func (m *MockOrderService) GetOrdersByTrackNumber(ctx context.Context, trackNumber string) ([]*models.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrdersByTrackNumber", ctx, trackNumber)
+	ret0, _ := ret[0].([]*models.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrdersByTrackNumber indicates an expected call of GetOrdersByTrackNumber.
+func (mr *MockOrderServiceMockRecorder) GetOrdersByTrackNumber(ctx, trackNumber any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrdersByTrackNumber", reflect.TypeOf((*MockOrderService)(nil).GetOrdersByTrackNumber), ctx, trackNumber)
+}
+
+// InvalidateOrder mocks base method.
+func (m *MockOrderService) InvalidateOrder(ctx context.Context, orderUID string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateOrder", ctx, orderUID)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// InvalidateOrder indicates an expected call of InvalidateOrder.
+func (mr *MockOrderServiceMockRecorder) InvalidateOrder(ctx, orderUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateOrder", reflect.TypeOf((*MockOrderService)(nil).InvalidateOrder), ctx, orderUID)
+}
+
+// InvalidateOrders mocks base method.
+func (m *MockOrderService) InvalidateOrders(ctx context.Context, orderUIDs []string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InvalidateOrders", ctx, orderUIDs)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// InvalidateOrders indicates an expected call of InvalidateOrders.
+func (mr *MockOrderServiceMockRecorder) InvalidateOrders(ctx, orderUIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateOrders", reflect.TypeOf((*MockOrderService)(nil).InvalidateOrders), ctx, orderUIDs)
+}
+
+// ItemStatuses mocks base method.
+func (m *MockOrderService) ItemStatuses() []itemstatus.Status {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ItemStatuses")
+	ret0, _ := ret[0].([]itemstatus.Status)
+	return ret0
+}
+
+// ItemStatuses indicates an expected call of ItemStatuses.
+func (mr *MockOrderServiceMockRecorder) ItemStatuses() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ItemStatuses", reflect.TypeOf((*MockOrderService)(nil).ItemStatuses))
+}
+
+// ListOrders mocks base method.
+func (m *MockOrderService) ListOrders(ctx context.Context, cursor string, limit int) (interfaces.OrderPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOrders", ctx, cursor, limit)
+	ret0, _ := ret[0].(interfaces.OrderPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOrders indicates an expected call of ListOrders.
+func (mr *MockOrderServiceMockRecorder) ListOrders(ctx, cursor, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOrders", reflect.TypeOf((*MockOrderService)(nil).ListOrders), ctx, cursor, limit)
+}
+
+// LookupItemStatus mocks base method.
+func (m *MockOrderService) LookupItemStatus(code int) itemstatus.Status {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LookupItemStatus", code)
+	ret0, _ := ret[0].(itemstatus.Status)
+	return ret0
+}
+
+// LookupItemStatus indicates an expected call of LookupItemStatus.
+func (mr *MockOrderServiceMockRecorder) LookupItemStatus(code any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LookupItemStatus", reflect.TypeOf((*MockOrderService)(nil).LookupItemStatus), code)
+}
+
 // ProcessOrder mocks base method.
-func (m *MockOrderService) ProcessOrder(ctx context.Context, order *models.Order) error {
+func (m *MockOrderService) ProcessOrder(ctx context.Context, order *models.Order) (models.OrderOutcome, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ProcessOrder", ctx, order)
-	ret0, _ := ret[0].(error)
-	return ret0
+	ret0, _ := ret[0].(models.OrderOutcome)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // ProcessOrder indicates an expected call of ProcessOrder.
@@ -84,16 +215,124 @@ func (mr *MockOrderServiceMockRecorder) ProcessOrder(ctx, order any) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessOrder", reflect.TypeOf((*MockOrderService)(nil).ProcessOrder), ctx, order)
 }
 
+// PurgeCache mocks base method.
+func (m *MockOrderService) PurgeCache(ctx context.Context) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeCache", ctx)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// PurgeCache indicates an expected call of PurgeCache.
+func (mr *MockOrderServiceMockRecorder) PurgeCache(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeCache", reflect.TypeOf((*MockOrderService)(nil).PurgeCache), ctx)
+}
+
+// RestoreCache mocks base method.
+func (m *MockOrderService) RestoreCache(ctx context.Context, opts interfaces.WarmUpOptions) (interfaces.WarmUp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCache", ctx, opts)
+	ret0, _ := ret[0].(interfaces.WarmUp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCache indicates an expected call of RestoreCache.
+func (mr *MockOrderServiceMockRecorder) RestoreCache(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCache", reflect.TypeOf((*MockOrderService)(nil).RestoreCache), ctx, opts)
+}
+
 // RestoreCacheFromDB mocks base method.
-func (m *MockOrderService) RestoreCacheFromDB(ctx context.Context) error {
+func (m *MockOrderService) RestoreCacheFromDB(ctx context.Context, opts interfaces.WarmUpOptions) (interfaces.WarmUp, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreCacheFromDB", ctx, opts)
+	ret0, _ := ret[0].(interfaces.WarmUp)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RestoreCacheFromDB indicates an expected call of RestoreCacheFromDB.
+func (mr *MockOrderServiceMockRecorder) RestoreCacheFromDB(ctx, opts any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCacheFromDB", reflect.TypeOf((*MockOrderService)(nil).RestoreCacheFromDB), ctx, opts)
+}
+
+// ResyncCache mocks base method.
+func (m *MockOrderService) ResyncCache(ctx context.Context) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ResyncCache", ctx)
+}
+
+// ResyncCache indicates an expected call of ResyncCache.
+func (mr *MockOrderServiceMockRecorder) ResyncCache(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResyncCache", reflect.TypeOf((*MockOrderService)(nil).ResyncCache), ctx)
+}
+
+// SaveCacheSnapshot mocks base method.
+func (m *MockOrderService) SaveCacheSnapshot(ctx context.Context) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RestoreCacheFromDB", ctx)
+	ret := m.ctrl.Call(m, "SaveCacheSnapshot", ctx)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// RestoreCacheFromDB indicates an expected call of RestoreCacheFromDB.
-func (mr *MockOrderServiceMockRecorder) RestoreCacheFromDB(ctx any) *gomock.Call {
+// SaveCacheSnapshot indicates an expected call of SaveCacheSnapshot.
+func (mr *MockOrderServiceMockRecorder) SaveCacheSnapshot(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveCacheSnapshot", reflect.TypeOf((*MockOrderService)(nil).SaveCacheSnapshot), ctx)
+}
+
+// MockWarmUp is a mock of WarmUp interface.
+type MockWarmUp struct {
+	ctrl     *gomock.Controller
+	recorder *MockWarmUpMockRecorder
+	isgomock struct{}
+}
+
+// MockWarmUpMockRecorder is the mock recorder for MockWarmUp.
+type MockWarmUpMockRecorder struct {
+	mock *MockWarmUp
+}
+
+// NewMockWarmUp creates a new mock instance.
+func NewMockWarmUp(ctrl *gomock.Controller) *MockWarmUp {
+	mock := &MockWarmUp{ctrl: ctrl}
+	mock.recorder = &MockWarmUpMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockWarmUp) EXPECT() *MockWarmUpMockRecorder {
+	return m.recorder
+}
+
+// Progress mocks base method.
+func (m *MockWarmUp) Progress() interfaces.WarmUpProgress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Progress")
+	ret0, _ := ret[0].(interfaces.WarmUpProgress)
+	return ret0
+}
+
+// Progress indicates an expected call of Progress.
+func (mr *MockWarmUpMockRecorder) Progress() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Progress", reflect.TypeOf((*MockWarmUp)(nil).Progress))
+}
+
+// Wait mocks base method.
+func (m *MockWarmUp) Wait(ctx context.Context) interfaces.WarmUpProgress {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Wait", ctx)
+	ret0, _ := ret[0].(interfaces.WarmUpProgress)
+	return ret0
+}
+
+// Wait indicates an expected call of Wait.
+func (mr *MockWarmUpMockRecorder) Wait(ctx any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreCacheFromDB", reflect.TypeOf((*MockOrderService)(nil).RestoreCacheFromDB), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wait", reflect.TypeOf((*MockWarmUp)(nil).Wait), ctx)
 }
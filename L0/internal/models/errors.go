@@ -0,0 +1,18 @@
+package models
+
+import "errors"
+
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrStaleVersion reports an order older than the version already stored.
+var ErrStaleVersion = errors.New("order version is older than stored version")
+
+var ErrInvalidStatusTransition = errors.New("invalid order status transition")
+
+// ErrStatusChanged reports a status update that lost a race with another
+// update of the same order.
+var ErrStatusChanged = errors.New("order status changed concurrently")
+
+// ErrOrderConflict reports an order whose UID is already stored with
+// different content.
+var ErrOrderConflict = errors.New("order conflicts with stored version")
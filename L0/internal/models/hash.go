@@ -0,0 +1,29 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// ContentHash returns a hex SHA-256 of the order as producers send it, so a
+// redelivered message hashes the same and any changed field does not.
+// DB-only fields are excluded by their json tags, and DateCreated is taken
+// in UTC so the same instant always hashes the same. Version is left out,
+// being compared on its own, and so is the service-owned status.
+func (o *Order) ContentHash() (string, error) {
+	normalized := *o
+	normalized.DateCreated = o.DateCreated.UTC()
+	normalized.Version = 0
+	normalized.Status = ""
+	normalized.StatusHistory = nil
+
+	data, err := json.Marshal(&normalized)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode order %s for hashing: %w", o.OrderUID, err)
+	}
+
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrder_ContentHash(t *testing.T) {
+	order := createValidOrder()
+	hash, err := order.ContentHash()
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	t.Run("redelivered message hashes the same", func(t *testing.T) {
+		data, err := json.Marshal(order)
+		require.NoError(t, err)
+		var replay Order
+		require.NoError(t, json.Unmarshal(data, &replay))
+
+		replayHash, err := replay.ContentHash()
+		require.NoError(t, err)
+		assert.Equal(t, hash, replayHash)
+	})
+
+	t.Run("same instant in another zone hashes the same", func(t *testing.T) {
+		moved := *order
+		moved.DateCreated = order.DateCreated.In(time.FixedZone("MSK", 3*60*60))
+
+		movedHash, err := moved.ContentHash()
+		require.NoError(t, err)
+		assert.Equal(t, hash, movedHash)
+	})
+
+	t.Run("DB-only fields are ignored", func(t *testing.T) {
+		stored := *order
+		stored.Items = append([]Item(nil), order.Items...)
+		stored.Items[0].ID = 42
+		stored.Items[0].OrderUID = order.OrderUID
+		stored.Delivery.OrderUID = order.OrderUID
+
+		storedHash, err := stored.ContentHash()
+		require.NoError(t, err)
+		assert.Equal(t, hash, storedHash)
+	})
+
+	t.Run("version and status are ignored", func(t *testing.T) {
+		corrected := *order
+		corrected.Version = 3
+		corrected.Status = StatusShipped
+		corrected.StatusHistory = []StatusChange{{Status: StatusCreated, Source: StatusSourceIngestion}}
+
+		correctedHash, err := corrected.ContentHash()
+		require.NoError(t, err)
+		assert.Equal(t, hash, correctedHash)
+	})
+
+	t.Run("changed field changes the hash", func(t *testing.T) {
+		changed := *order
+		changed.Items = append([]Item(nil), order.Items...)
+		changed.Items[0].Price++
+
+		changedHash, err := changed.ContentHash()
+		require.NoError(t, err)
+		assert.NotEqual(t, hash, changedHash)
+	})
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	policy, err := ParseConflictPolicy(" Replace ")
+	require.NoError(t, err)
+	assert.Equal(t, ConflictReplace, policy)
+
+	policy, err = ParseConflictPolicy("reject")
+	require.NoError(t, err)
+	assert.Equal(t, ConflictReject, policy)
+
+	_, err = ParseConflictPolicy("merge")
+	assert.Error(t, err)
+}
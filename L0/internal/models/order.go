@@ -2,6 +2,9 @@ package models
 
 import "time"
 
+// InitialOrderVersion is the version of an order sent without one.
+const InitialOrderVersion int64 = 1
+
 type Order struct {
 	OrderUID          string    `json:"order_uid" db:"order_uid"`
 	TrackNumber       string    `json:"track_number" db:"track_number"`
@@ -17,6 +20,13 @@ type Order struct {
 	SmID              int       `json:"sm_id" db:"sm_id"`
 	DateCreated       time.Time `json:"date_created" db:"date_created"`
 	OofShard          string    `json:"oof_shard" db:"oof_shard"`
+	// Version increases with every correction of the order. Stored orders
+	// always have one; omitempty keeps it out of ContentHash.
+	Version int64 `json:"version,omitempty" db:"version"`
+	// Status and StatusHistory are kept by the service, never taken from
+	// producers. History is oldest first.
+	Status        OrderStatus    `json:"status,omitempty" db:"status"`
+	StatusHistory []StatusChange `json:"status_history,omitempty" db:"-"`
 }
 
 type Delivery struct {
@@ -59,3 +69,18 @@ type Item struct {
 	Brand       string `json:"brand" db:"brand"`
 	Status      int    `json:"status" db:"status"`
 }
+
+// OrdersSummary is a cheap fingerprint of the orders table, used to tell
+// whether a cache snapshot still matches the database.
+type OrdersSummary struct {
+	Count             int64
+	LatestDateCreated time.Time
+	// RevisionSum adds up the order revisions, which change on every write,
+	// so in-place updates change it too.
+	RevisionSum int64
+}
+
+func (s OrdersSummary) Equal(other OrdersSummary) bool {
+	return s.Count == other.Count && s.LatestDateCreated.Equal(other.LatestDateCreated) &&
+		s.RevisionSum == other.RevisionSum
+}
@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OrderOutcome tells what saving an order did, given what was already
+// stored under its UID. Duplicate, conflict and replaced all mean the stored
+// order has the same version.
+type OrderOutcome int
+
+const (
+	// OutcomeCreated stored an order with a new UID.
+	OutcomeCreated OrderOutcome = iota + 1
+	// OutcomeDuplicate matched the stored order exactly; nothing was written.
+	OutcomeDuplicate
+	// OutcomeConflict differed from the stored order, which was kept.
+	OutcomeConflict
+	// OutcomeReplaced differed from the stored order and replaced it.
+	OutcomeReplaced
+	// OutcomeUpdated was a newer version of the stored order and replaced it.
+	OutcomeUpdated
+	// OutcomeStale was older than the stored version; nothing was written.
+	OutcomeStale
+)
+
+func (o OrderOutcome) String() string {
+	switch o {
+	case OutcomeCreated:
+		return "created"
+	case OutcomeDuplicate:
+		return "duplicate"
+	case OutcomeConflict:
+		return "conflict"
+	case OutcomeReplaced:
+		return "replaced"
+	case OutcomeUpdated:
+		return "updated"
+	case OutcomeStale:
+		return "stale"
+	default:
+		return fmt.Sprintf("OrderOutcome(%d)", int(o))
+	}
+}
+
+// ConflictPolicy decides what happens to an order whose UID is already
+// stored with different content.
+type ConflictPolicy int
+
+const (
+	// ConflictReject keeps the stored order and fails the new one with
+	// ErrOrderConflict.
+	ConflictReject ConflictPolicy = iota
+	// ConflictReplace overwrites the stored order with the new one.
+	ConflictReplace
+)
+
+func (p ConflictPolicy) String() string {
+	switch p {
+	case ConflictReject:
+		return "reject"
+	case ConflictReplace:
+		return "replace"
+	default:
+		return fmt.Sprintf("ConflictPolicy(%d)", int(p))
+	}
+}
+
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "reject":
+		return ConflictReject, nil
+	case "replace":
+		return ConflictReplace, nil
+	default:
+		return 0, fmt.Errorf("unknown conflict policy %q", s)
+	}
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// OrderStatus is the lifecycle state of an order as a whole.
+type OrderStatus string
+
+const (
+	StatusCreated    OrderStatus = "created"
+	StatusPaid       OrderStatus = "paid"
+	StatusAssembling OrderStatus = "assembling"
+	StatusShipped    OrderStatus = "shipped"
+	StatusDelivered  OrderStatus = "delivered"
+	StatusCancelled  OrderStatus = "cancelled"
+	StatusReturned   OrderStatus = "returned"
+)
+
+// StatusSourceIngestion is the source recorded for the created status of
+// an order saved by ProcessOrder.
+const StatusSourceIngestion = "ingestion"
+
+// statusTransitions lists the statuses each status may move to. Cancelled
+// and returned are final.
+var statusTransitions = map[OrderStatus][]OrderStatus{
+	StatusCreated:    {StatusPaid, StatusCancelled},
+	StatusPaid:       {StatusAssembling, StatusCancelled},
+	StatusAssembling: {StatusShipped, StatusCancelled},
+	StatusShipped:    {StatusDelivered, StatusReturned},
+	StatusDelivered:  {StatusReturned},
+	StatusCancelled:  nil,
+	StatusReturned:   nil,
+}
+
+func ParseOrderStatus(s string) (OrderStatus, error) {
+	status := OrderStatus(strings.ToLower(strings.TrimSpace(s)))
+	if !status.Valid() {
+		return "", fmt.Errorf("unknown order status %q", s)
+	}
+	return status, nil
+}
+
+func (s OrderStatus) Valid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange is one entry of an order's status history.
+type StatusChange struct {
+	Status    OrderStatus `json:"status"`
+	ChangedAt time.Time   `json:"changed_at"`
+	// Source names who made the change, e.g. a service or an operator.
+	Source string `json:"source"`
+}
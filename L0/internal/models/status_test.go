@@ -0,0 +1,46 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderStatus_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from, to OrderStatus
+		allowed  bool
+	}{
+		{StatusCreated, StatusPaid, true},
+		{StatusCreated, StatusCancelled, true},
+		{StatusCreated, StatusShipped, false},
+		{StatusPaid, StatusAssembling, true},
+		{StatusPaid, StatusCreated, false},
+		{StatusAssembling, StatusShipped, true},
+		{StatusAssembling, StatusCancelled, true},
+		{StatusShipped, StatusDelivered, true},
+		{StatusShipped, StatusCancelled, false},
+		{StatusDelivered, StatusReturned, true},
+		{StatusDelivered, StatusDelivered, false},
+		{StatusCancelled, StatusPaid, false},
+		{StatusReturned, StatusDelivered, false},
+		{OrderStatus("lost"), StatusCreated, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.allowed, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestParseOrderStatus(t *testing.T) {
+	status, err := ParseOrderStatus(" Shipped ")
+	require.NoError(t, err)
+	assert.Equal(t, StatusShipped, status)
+
+	_, err = ParseOrderStatus("lost")
+	assert.Error(t, err)
+	assert.False(t, OrderStatus("").Valid())
+}
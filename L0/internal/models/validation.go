@@ -4,9 +4,24 @@ import (
 	"fmt"
 	"regexp"
 	"time"
+
+	"L0/internal/clock"
 )
 
-type Validator struct{}
+const maxDateCreatedSkew = 24 * time.Hour
+
+// ItemStatusCatalog tells which item status codes exist.
+type ItemStatusCatalog interface {
+	Known(code int) bool
+}
+
+type Validator struct {
+	// Clock defaults to the system clock when nil.
+	Clock clock.Clock
+	// ItemStatuses enables strict mode: items whose status it does not know
+	// are rejected. Nil accepts any non-negative status.
+	ItemStatuses ItemStatusCatalog
+}
 
 func (v *Validator) ValidateOrder(order *Order) error {
 	if order == nil {
@@ -65,7 +80,11 @@ func (v *Validator) validateOrderMain(order *Order) error {
 		return fmt.Errorf("sm_id cannot be negative")
 	}
 
-	if order.DateCreated.After(time.Now().Add(24 * time.Hour)) {
+	if order.Version < 0 {
+		return fmt.Errorf("version cannot be negative")
+	}
+
+	if order.DateCreated.After(v.now().Add(maxDateCreatedSkew)) {
 		return fmt.Errorf("date_created cannot be in the future")
 	}
 
@@ -211,9 +230,20 @@ func (v *Validator) validateItem(item *Item, index int) error {
 		return fmt.Errorf("item[%d]: status cannot be negative", index)
 	}
 
+	if v.ItemStatuses != nil && !v.ItemStatuses.Known(item.Status) {
+		return fmt.Errorf("item[%d]: unknown status %d", index, item.Status)
+	}
+
 	return nil
 }
 
+func (v *Validator) now() time.Time {
+	if v.Clock == nil {
+		return time.Now()
+	}
+	return v.Clock.Now()
+}
+
 func (v *Validator) isValidPhone(phone string) bool {
 	if phone == "" {
 		return false
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"L0/internal/clock"
+
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,6 +89,14 @@ func TestValidator_ValidateOrder(t *testing.T) {
 		assert.Contains(t, err.Error(), "track_number is required")
 	})
 
+	t.Run("negative version", func(t *testing.T) {
+		order := createValidOrder()
+		order.Version = -1
+		err := validator.ValidateOrder(order)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "version cannot be negative")
+	})
+
 	t.Run("invalid delivery email", func(t *testing.T) {
 		order := createValidOrder()
 		order.Delivery.Email = "invalid-email"
@@ -177,3 +187,68 @@ func TestValidator_IsValidPhone(t *testing.T) {
 		assert.False(t, validator.isValidPhone(phone), "Phone should be invalid: %s", phone)
 	}
 }
+
+func TestValidator_DateCreatedFutureLimit(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	validator := &Validator{Clock: clock.NewFake(now)}
+
+	testCases := []struct {
+		name        string
+		dateCreated time.Time
+		wantErr     bool
+	}{
+		{"in the past", now.Add(-30 * 24 * time.Hour), false},
+		{"now", now, false},
+		{"exactly at the limit", now.Add(maxDateCreatedSkew), false},
+		{"just past the limit", now.Add(maxDateCreatedSkew + time.Nanosecond), true},
+		{"far future", now.Add(365 * 24 * time.Hour), true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			order := createValidOrder()
+			order.DateCreated = tc.dateCreated
+
+			err := validator.ValidateOrder(order)
+			if tc.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "date_created cannot be in the future")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestValidator_DateCreatedFollowsClock(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	fake := clock.NewFake(now)
+	validator := &Validator{Clock: fake}
+
+	order := createValidOrder()
+	order.DateCreated = now.Add(48 * time.Hour)
+	require.Error(t, validator.ValidateOrder(order))
+
+	fake.Advance(24 * time.Hour)
+	require.NoError(t, validator.ValidateOrder(order))
+}
+
+type knownStatuses map[int]bool
+
+func (k knownStatuses) Known(code int) bool { return k[code] }
+
+func TestValidator_StrictItemStatus(t *testing.T) {
+	order := createValidOrder()
+	order.Items[0].Status = 999
+
+	lenient := &Validator{}
+	require.NoError(t, lenient.ValidateOrder(order))
+
+	strict := &Validator{ItemStatuses: knownStatuses{200: true}}
+	err := strict.ValidateOrder(order)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "item[0]: unknown status 999")
+
+	order.Items[0].Status = 200
+	require.NoError(t, strict.ValidateOrder(order))
+}
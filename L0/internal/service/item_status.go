@@ -0,0 +1,14 @@
+package service
+
+import "L0/internal/itemstatus"
+
+// ItemStatuses returns the item status catalog ordered by code.
+func (s *OrderService) ItemStatuses() []itemstatus.Status {
+	return itemstatus.Default().Statuses()
+}
+
+// LookupItemStatus describes an item status code. Codes missing from the
+// catalog are returned with Known set to false.
+func (s *OrderService) LookupItemStatus(code int) itemstatus.Status {
+	return itemstatus.Default().Lookup(code)
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"testing"
+
+	"L0/internal/itemstatus"
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderService_StrictItemStatus(t *testing.T) {
+	lenient := newOrderService(nil, nil, DefaultOptions())
+	validator, ok := lenient.validator.(*models.Validator)
+	require.True(t, ok)
+	assert.Nil(t, validator.ItemStatuses)
+
+	opts := DefaultOptions()
+	opts.StrictItemStatus = true
+	strict := newOrderService(nil, nil, opts)
+	validator, ok = strict.validator.(*models.Validator)
+	require.True(t, ok)
+	assert.Same(t, itemstatus.Default(), validator.ItemStatuses)
+}
+
+func TestOrderService_ItemStatuses(t *testing.T) {
+	service := newOrderService(nil, nil, DefaultOptions())
+
+	statuses := service.ItemStatuses()
+	require.NotEmpty(t, statuses)
+	assert.Equal(t, itemstatus.Default().Statuses(), statuses)
+
+	assert.True(t, service.LookupItemStatus(statuses[0].Code).Known)
+	assert.False(t, service.LookupItemStatus(-1).Known)
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"fmt"
+	"time"
+
+	"L0/internal/models"
+)
+
+const defaultLoadTimeout = 10 * time.Second
+
+type Options struct {
+	// LoadTimeout bounds a DB load shared by concurrent GetOrder callers,
+	// which does not end with any one caller's context.
+	LoadTimeout time.Duration
+	// NegativeTTL is how long a confirmed "order not found" is remembered.
+	// Zero disables negative caching.
+	NegativeTTL        time.Duration
+	NegativeMaxEntries int
+	// MembershipFilter enables a Bloom filter of known order UIDs, built by
+	// RestoreCacheFromDB, that lets definitely-absent UIDs skip the DB. Only
+	// enable it when this instance sees every order write.
+	MembershipFilter        bool
+	FilterFalsePositiveRate float64
+	// SnapshotPath is where the cache is saved on shutdown and restored from
+	// on startup. Empty disables snapshots.
+	SnapshotPath string
+	// SnapshotMaxAge is how old a snapshot may be and still be trusted.
+	SnapshotMaxAge time.Duration
+	// ConflictPolicy decides what ProcessOrder does with an order whose UID
+	// is already stored with different content.
+	ConflictPolicy models.ConflictPolicy
+	// StrictItemStatus rejects orders with item status codes missing from
+	// the item status catalog.
+	StrictItemStatus bool
+}
+
+func DefaultOptions() Options {
+	return Options{
+		LoadTimeout:             defaultLoadTimeout,
+		NegativeTTL:             30 * time.Second,
+		NegativeMaxEntries:      10000,
+		MembershipFilter:        false,
+		FilterFalsePositiveRate: 0.01,
+		SnapshotMaxAge:          time.Hour,
+		ConflictPolicy:          models.ConflictReject,
+		StrictItemStatus:        false,
+	}
+}
+
+func (o Options) Validate() error {
+	if o.LoadTimeout <= 0 {
+		return fmt.Errorf("load timeout must be positive, got %s", o.LoadTimeout)
+	}
+
+	if o.NegativeTTL < 0 {
+		return fmt.Errorf("negative cache TTL cannot be negative, got %s", o.NegativeTTL)
+	}
+
+	if o.NegativeTTL > 0 && o.NegativeMaxEntries <= 0 {
+		return fmt.Errorf("negative cache max entries must be positive, got %d", o.NegativeMaxEntries)
+	}
+
+	if o.MembershipFilter && (o.FilterFalsePositiveRate <= 0 || o.FilterFalsePositiveRate >= 1) {
+		return fmt.Errorf("filter false positive rate must be in (0, 1), got %v", o.FilterFalsePositiveRate)
+	}
+
+	if o.SnapshotPath != "" && o.SnapshotMaxAge <= 0 {
+		return fmt.Errorf("snapshot max age must be positive, got %s", o.SnapshotMaxAge)
+	}
+
+	if o.ConflictPolicy != models.ConflictReject && o.ConflictPolicy != models.ConflictReplace {
+		return fmt.Errorf("unknown conflict policy %s", o.ConflictPolicy)
+	}
+
+	return nil
+}
@@ -2,11 +2,20 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sync"
+	"sync/atomic"
+	"time"
 
+	"L0/internal/bloom"
+	"L0/internal/cache"
 	"L0/internal/interfaces"
+	"L0/internal/itemstatus"
 	"L0/internal/models"
+
+	"golang.org/x/sync/singleflight"
 )
 
 var _ interfaces.OrderService = (*OrderService)(nil)
@@ -15,59 +24,153 @@ type OrderService struct {
 	orderRepo interfaces.Repository
 	cache     interfaces.Cache
 	validator interfaces.Validator
+	loads     singleflight.Group
+	negative  *cache.NegativeCache
+	filter    atomic.Pointer[bloom.Filter]
+	// filterMu orders markKnown against a filter rebuild: UIDs marked while
+	// the rebuild reads the DB are kept in pendingUIDs and added to the new
+	// filter, which the DB read may have missed.
+	filterMu    sync.Mutex
+	rebuilding  bool
+	pendingUIDs []string
+	opts        Options
 }
 
 func NewOrderService(orderRepo interfaces.Repository, cache interfaces.Cache) interfaces.OrderService {
-	return &OrderService{
+	return newOrderService(orderRepo, cache, DefaultOptions())
+}
+
+func NewOrderServiceWithOptions(orderRepo interfaces.Repository, cache interfaces.Cache, opts Options) (interfaces.OrderService, error) {
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid service options: %w", err)
+	}
+	return newOrderService(orderRepo, cache, opts), nil
+}
+
+func newOrderService(orderRepo interfaces.Repository, orderCache interfaces.Cache, opts Options) *OrderService {
+	validator := &models.Validator{}
+	if opts.StrictItemStatus {
+		validator.ItemStatuses = itemstatus.Default()
+	}
+
+	s := &OrderService{
 		orderRepo: orderRepo,
-		cache:     cache,
-		validator: &models.Validator{},
+		cache:     orderCache,
+		validator: validator,
+		opts:      opts,
 	}
+	if opts.NegativeTTL > 0 {
+		s.negative = cache.NewNegativeCache(opts.NegativeTTL, opts.NegativeMaxEntries, nil)
+	}
+	return s
 }
 
-func (s *OrderService) RestoreCacheFromDB(ctx context.Context) error {
+// RestoreCacheFromDB loads the orders selected by opts into the cache. With
+// opts.Background it returns once the order UIDs are known and loads them in
+// the background; the returned WarmUp reports how far it got.
+func (s *OrderService) RestoreCacheFromDB(ctx context.Context, opts interfaces.WarmUpOptions) (interfaces.WarmUp, error) {
+	if err := validateWarmUpOptions(opts); err != nil {
+		return nil, err
+	}
 	log.Println("Restoring cache from database")
 
-	orderUIDs, err := s.orderRepo.GetAllOrderUIDs(ctx)
+	orderUIDs, err := s.warmUpOrderUIDs(ctx, opts)
 	if err != nil {
-		return fmt.Errorf("failed to get orderUID for cache restoration: %w", err)
+		return nil, fmt.Errorf("failed to get orderUID for cache restoration: %w", err)
 	}
 
-	count := 0
-	for _, orderUID := range orderUIDs {
-		order, err := s.orderRepo.GetOrderByUID(ctx, orderUID)
+	w := newWarmUp(len(orderUIDs))
+	run := func() {
+		s.loadIntoCache(ctx, orderUIDs, max(opts.Parallelism, 1), w)
+		w.finish()
+		progress := w.Progress()
+		log.Printf("Cache restore. Loaded %d of %d orders, %d failed, in %s",
+			progress.Loaded, progress.Total, progress.Failed, progress.Elapsed.Round(time.Millisecond))
+	}
+
+	if opts.Background {
+		go run()
+	} else {
+		run()
+	}
+	return w, nil
+}
+
+func (s *OrderService) warmUpOrderUIDs(ctx context.Context, opts interfaces.WarmUpOptions) ([]string, error) {
+	bounded := opts.Limit > 0 || opts.MaxAge > 0
+	if !bounded || s.opts.MembershipFilter {
+		// The filter needs every UID, even when only some are cached.
+		allUIDs, err := s.orderRepo.GetAllOrderUIDs(ctx)
 		if err != nil {
-			log.Printf("Error restoring order %s: %v", orderUID, err)
-			continue
+			return nil, err
 		}
-
-		if err := s.cache.Set(order); err != nil {
-			log.Printf("Failed to cache order %s: %v", orderUID, err)
-			continue
+		if s.opts.MembershipFilter {
+			s.buildFilter(allUIDs)
+		}
+		if !bounded {
+			return allUIDs, nil
 		}
-		count++
 	}
 
-	log.Printf("Cache restore. Loaded %d orders", count)
-	return nil
+	var since time.Time
+	if opts.MaxAge > 0 {
+		since = time.Now().Add(-opts.MaxAge)
+	}
+	return s.orderRepo.GetRecentOrderUIDs(ctx, opts.Limit, since)
 }
 
-func (s *OrderService) ProcessOrder(ctx context.Context, order *models.Order) error {
+// ProcessOrder saves order to the DB and caches it only once the save has
+// committed, so the cache never serves an order the DB does not have.
+// Redelivered duplicates are skipped, and an order older than the stored
+// version fails with models.ErrStaleVersion. Different content at the
+// stored version is handled by the configured ConflictPolicy and, when
+// rejected, fails with models.ErrOrderConflict.
+func (s *OrderService) ProcessOrder(ctx context.Context, order *models.Order) (models.OrderOutcome, error) {
 	if err := s.validator.ValidateOrder(order); err != nil {
-		return fmt.Errorf("order validation failed: %w", err)
+		return 0, fmt.Errorf("order validation failed: %w", err)
 	}
+	if order.Version == 0 {
+		order.Version = models.InitialOrderVersion
+	}
+
+	// Cache writes and deletes below must not depend on the caller staying
+	// around: a skipped delete leaves a stale order behind.
+	cacheCtx := context.WithoutCancel(ctx)
 
-	if err := s.cache.Set(order); err != nil {
-		return fmt.Errorf("failed to cache order: %w", err)
+	outcome, err := s.orderRepo.SaveOrder(ctx, order, s.opts.ConflictPolicy)
+	if err != nil {
+		// The DB may still hold an older version than the cached one, or
+		// none at all; let the next read reload whatever was committed.
+		s.cache.Delete(cacheCtx, order.OrderUID)
+		return 0, fmt.Errorf("failed to save order to DB: %w", err)
 	}
-	log.Printf("Order cached: %s", order.OrderUID)
 
-	if err := s.orderRepo.SaveOrder(ctx, order); err != nil {
-		return fmt.Errorf("failed to save order to DB: %w", err)
+	switch outcome {
+	case models.OutcomeDuplicate:
+		log.Printf("Duplicate order skipped: %s", order.OrderUID)
+		return outcome, nil
+	case models.OutcomeConflict:
+		return outcome, fmt.Errorf("order %s: %w", order.OrderUID, models.ErrOrderConflict)
+	case models.OutcomeStale:
+		return outcome, fmt.Errorf("order %s version %d: %w", order.OrderUID, order.Version, models.ErrStaleVersion)
 	}
+	s.markKnown(order.OrderUID)
 
-	log.Printf("Order processed successfully(Service): %s", order.OrderUID)
-	return nil
+	if err := s.cache.Set(cacheCtx, order); err != nil {
+		// Drop any previous version so it is not served in place of the
+		// committed one.
+		s.cache.Delete(cacheCtx, order.OrderUID)
+		if errors.Is(err, cache.ErrOrderTooLarge) {
+			log.Printf("Order not cached: %v", err)
+		} else {
+			log.Printf("Warning: failed to cache order %s: %v", order.OrderUID, err)
+		}
+	} else {
+		log.Printf("Order cached: %s", order.OrderUID)
+	}
+
+	log.Printf("Order processed successfully(Service): %s (%s)", order.OrderUID, outcome)
+	return outcome, nil
 }
 
 func (s *OrderService) GetOrder(ctx context.Context, orderUID string) (*models.Order, error) {
@@ -75,22 +178,249 @@ func (s *OrderService) GetOrder(ctx context.Context, orderUID string) (*models.O
 		return nil, fmt.Errorf("orderUID cannot be empty")
 	}
 
-	if order, exists := s.cache.Get(orderUID); exists {
+	order, exists, err := s.cache.Get(ctx, orderUID)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		// A failing cache should not take reads down with it.
+		log.Printf("Warning: cache lookup for order %s failed: %v", orderUID, err)
+	}
+	if exists {
 		return order, nil
 	}
 
+	if s.isKnownMissing(orderUID) {
+		return nil, fmt.Errorf("failed to get order %s: %w", orderUID, models.ErrOrderNotFound)
+	}
+
+	// Concurrent misses for the same UID share a single DB load. The load is
+	// detached from the caller's context so one caller giving up does not
+	// fail the others; each caller still stops waiting when its own ctx ends.
+	// The load timeout keeps a hung query from holding the UID forever.
+	result := s.loads.DoChan(orderUID, func() (any, error) {
+		loadCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), s.loadTimeout())
+		defer cancel()
+		return s.loadOrder(loadCtx, orderUID)
+	})
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case res := <-result:
+		if res.Err != nil {
+			return nil, res.Err
+		}
+		return res.Val.(*models.Order), nil
+	}
+}
+
+func (s *OrderService) loadTimeout() time.Duration {
+	if s.opts.LoadTimeout <= 0 {
+		return defaultLoadTimeout
+	}
+	return s.opts.LoadTimeout
+}
+
+func (s *OrderService) loadOrder(ctx context.Context, orderUID string) (*models.Order, error) {
 	order, err := s.orderRepo.GetOrderByUID(ctx, orderUID)
 	if err != nil {
+		if errors.Is(err, models.ErrOrderNotFound) && s.negative != nil {
+			s.negative.Add(orderUID)
+		}
 		return nil, fmt.Errorf("failed to get order from DB: %w", err)
 	}
 
-	if err := s.cache.Set(order); err != nil {
+	if err := s.cache.Set(ctx, order); err != nil {
 		log.Printf("Warning: failed to cache order %s: %v", orderUID, err)
 	}
 
 	return order, nil
 }
 
-func (s *OrderService) GetAllOrders() []*models.Order {
-	return s.cache.GetAll()
+func (s *OrderService) GetOrdersByTrackNumber(ctx context.Context, trackNumber string) ([]*models.Order, error) {
+	if trackNumber == "" {
+		return nil, fmt.Errorf("track number cannot be empty")
+	}
+
+	orderUIDs, err := s.orderRepo.GetOrderUIDsByTrackNumber(ctx, trackNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orders by track number: %w", err)
+	}
+
+	return s.getOrders(ctx, orderUIDs)
+}
+
+func (s *OrderService) GetOrdersByCustomerID(ctx context.Context, customerID string) ([]*models.Order, error) {
+	if customerID == "" {
+		return nil, fmt.Errorf("customer ID cannot be empty")
+	}
+
+	orderUIDs, err := s.orderRepo.GetOrderUIDsByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orders by customer ID: %w", err)
+	}
+
+	return s.getOrders(ctx, orderUIDs)
+}
+
+// getOrders returns the orders for UIDs the DB has just reported, in the
+// same order. The cache may hold only some of them; the rest are loaded in
+// one batch. The negative cache and the filter are skipped: a UID from the
+// DB exists even if they say otherwise. Orders deleted in between are left
+// out.
+func (s *OrderService) getOrders(ctx context.Context, orderUIDs []string) ([]*models.Order, error) {
+	found := make(map[string]*models.Order, len(orderUIDs))
+	var misses []string
+	for _, orderUID := range orderUIDs {
+		order, exists, err := s.cache.Get(ctx, orderUID)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			log.Printf("Warning: cache lookup for order %s failed: %v", orderUID, err)
+		}
+		if exists {
+			found[orderUID] = order
+			continue
+		}
+		misses = append(misses, orderUID)
+	}
+
+	if len(misses) > 0 {
+		loaded, err := s.orderRepo.GetOrdersByUIDs(ctx, misses)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get orders from DB: %w", err)
+		}
+		for _, order := range loaded {
+			found[order.OrderUID] = order
+			s.markKnown(order.OrderUID)
+			if err := s.cache.Set(ctx, order); err != nil {
+				log.Printf("Warning: failed to cache order %s: %v", order.OrderUID, err)
+			}
+		}
+	}
+
+	orders := make([]*models.Order, 0, len(orderUIDs))
+	for _, orderUID := range orderUIDs {
+		if order, ok := found[orderUID]; ok {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+// isKnownMissing reports whether orderUID can be answered as "not found"
+// without touching the DB.
+func (s *OrderService) isKnownMissing(orderUID string) bool {
+	if s.negative != nil && s.negative.Contains(orderUID) {
+		return true
+	}
+
+	if filter := s.filter.Load(); filter != nil && !filter.MayContain(orderUID) {
+		return true
+	}
+
+	return false
+}
+
+func (s *OrderService) markKnown(orderUID string) {
+	if s.negative != nil {
+		s.negative.Remove(orderUID)
+	}
+
+	s.filterMu.Lock()
+	defer s.filterMu.Unlock()
+	if s.rebuilding {
+		s.pendingUIDs = append(s.pendingUIDs, orderUID)
+	}
+	if filter := s.filter.Load(); filter != nil {
+		filter.Add(orderUID)
+	}
+}
+
+func (s *OrderService) buildFilter(orderUIDs []string) {
+	// Leave headroom for orders ingested after startup.
+	filter := bloom.New(max(2*len(orderUIDs), 1024), s.opts.FilterFalsePositiveRate)
+	for _, orderUID := range orderUIDs {
+		filter.Add(orderUID)
+	}
+	s.filter.Store(filter)
+	log.Printf("Order membership filter built with %d UIDs", len(orderUIDs))
+}
+
+func (s *OrderService) ListOrders(ctx context.Context, cursor string, limit int) (interfaces.OrderPage, error) {
+	page, err := s.cache.List(ctx, cursor, limit)
+	if err != nil {
+		return interfaces.OrderPage{}, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return page, nil
+}
+
+func (s *OrderService) CacheStats() interfaces.CacheStats {
+	return s.cache.Stats()
+}
+
+// InvalidateOrder drops orderUID from the cache, so the next read reloads it
+// from the DB. It also forgets a cached "not found" for it.
+func (s *OrderService) InvalidateOrder(ctx context.Context, orderUID string) bool {
+	if s.negative != nil {
+		s.negative.Remove(orderUID)
+	}
+	return s.cache.Delete(ctx, orderUID)
+}
+
+// ApplyOrderChange handles a write another instance made to orderUID. The
+// cached copy is dropped rather than reloaded, so orders nobody reads here
+// cost no query; the next read loads the committed version.
+func (s *OrderService) ApplyOrderChange(ctx context.Context, orderUID string) {
+	s.markKnown(orderUID)
+	s.cache.Delete(ctx, orderUID)
+}
+
+func (s *OrderService) InvalidateOrders(ctx context.Context, orderUIDs []string) int {
+	if s.negative != nil {
+		for _, orderUID := range orderUIDs {
+			s.negative.Remove(orderUID)
+		}
+	}
+	return s.cache.DeleteMany(ctx, orderUIDs)
+}
+
+// ResyncCache forgets everything this instance may have missed while it was
+// not receiving order changes: the cache, the negative cache and the
+// membership filter. The filter is dropped at once and rebuilt from the DB;
+// until then every miss goes to the DB.
+func (s *OrderService) ResyncCache(ctx context.Context) {
+	s.filterMu.Lock()
+	s.filter.Store(nil)
+	s.rebuilding = s.opts.MembershipFilter
+	s.pendingUIDs = nil
+	s.filterMu.Unlock()
+
+	s.PurgeCache(ctx)
+
+	if !s.opts.MembershipFilter {
+		return
+	}
+	orderUIDs, err := s.orderRepo.GetAllOrderUIDs(ctx)
+
+	s.filterMu.Lock()
+	defer s.filterMu.Unlock()
+	if err != nil {
+		log.Printf("Warning: order membership filter dropped, failed to rebuild it: %v", err)
+	} else {
+		s.buildFilter(append(orderUIDs, s.pendingUIDs...))
+	}
+	s.rebuilding = false
+	s.pendingUIDs = nil
+}
+
+func (s *OrderService) PurgeCache(ctx context.Context) int {
+	if s.negative != nil {
+		s.negative.Clear()
+	}
+	purged := s.cache.Purge(ctx)
+	log.Printf("Cache purged. Dropped %d orders", purged)
+	return purged
 }
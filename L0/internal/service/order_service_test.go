@@ -3,8 +3,14 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
+	"L0/internal/bloom"
+	"L0/internal/cache"
+	"L0/internal/interfaces"
 	"L0/internal/mocks"
 	"L0/internal/models"
 
@@ -32,45 +38,232 @@ func TestOrderService_ProcessOrder(t *testing.T) {
 
 	t.Run("successful processing", func(t *testing.T) {
 		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
-		mockCache.EXPECT().Set(order).Return(nil)
-		mockRepo.EXPECT().SaveOrder(ctx, order).Return(nil)
+		gomock.InOrder(
+			mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeCreated, nil),
+			mockCache.EXPECT().Set(gomock.Any(), order).Return(nil),
+		)
 
-		err := service.ProcessOrder(ctx, order)
+		outcome, err := service.ProcessOrder(ctx, order)
 
 		require.NoError(t, err)
+		assert.Equal(t, models.OutcomeCreated, outcome)
+	})
+
+	t.Run("exact duplicate is skipped", func(t *testing.T) {
+		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
+		mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeDuplicate, nil)
+
+		outcome, err := service.ProcessOrder(ctx, order)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.OutcomeDuplicate, outcome)
+	})
+
+	t.Run("conflicting duplicate is rejected", func(t *testing.T) {
+		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
+		mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeConflict, nil)
+
+		outcome, err := service.ProcessOrder(ctx, order)
+
+		require.ErrorIs(t, err, models.ErrOrderConflict)
+		assert.Equal(t, models.OutcomeConflict, outcome)
+	})
+
+	t.Run("older version is stale", func(t *testing.T) {
+		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
+		mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeStale, nil)
+
+		outcome, err := service.ProcessOrder(ctx, order)
+
+		require.ErrorIs(t, err, models.ErrStaleVersion)
+		assert.Equal(t, models.OutcomeStale, outcome)
+	})
+
+	t.Run("order without a version gets the initial one", func(t *testing.T) {
+		unversioned := &models.Order{OrderUID: "unversioned"}
+		mockValidator.EXPECT().ValidateOrder(unversioned).Return(nil)
+		mockRepo.EXPECT().SaveOrder(ctx, unversioned, models.ConflictReject).Return(models.OutcomeCreated, nil)
+		mockCache.EXPECT().Set(gomock.Any(), unversioned).Return(nil)
+
+		_, err := service.ProcessOrder(ctx, unversioned)
+
+		require.NoError(t, err)
+		assert.Equal(t, models.InitialOrderVersion, unversioned.Version)
 	})
 
 	t.Run("validation failed", func(t *testing.T) {
 		mockValidator.EXPECT().ValidateOrder(order).Return(errors.New("validation error"))
 
-		err := service.ProcessOrder(ctx, order)
+		_, err := service.ProcessOrder(ctx, order)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 	})
 
-	t.Run("cache set failed", func(t *testing.T) {
+	t.Run("cache set failed after commit", func(t *testing.T) {
 		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
-		mockCache.EXPECT().Set(order).Return(errors.New("cache error"))
+		gomock.InOrder(
+			mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeCreated, nil),
+			mockCache.EXPECT().Set(gomock.Any(), order).Return(errors.New("cache error")),
+			mockCache.EXPECT().Delete(gomock.Any(), "test-123").Return(true),
+		)
 
-		err := service.ProcessOrder(ctx, order)
+		_, err := service.ProcessOrder(ctx, order)
 
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to cache order")
+		require.NoError(t, err)
+	})
+
+	t.Run("order too large for cache is still saved", func(t *testing.T) {
+		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
+		gomock.InOrder(
+			mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeCreated, nil),
+			mockCache.EXPECT().Set(gomock.Any(), order).Return(fmt.Errorf("order test-123: %w", cache.ErrOrderTooLarge)),
+			mockCache.EXPECT().Delete(gomock.Any(), "test-123").Return(false),
+		)
+
+		_, err := service.ProcessOrder(ctx, order)
+
+		require.NoError(t, err)
 	})
 
 	t.Run("repository save failed", func(t *testing.T) {
 		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
-		mockCache.EXPECT().Set(order).Return(nil)
-		mockRepo.EXPECT().SaveOrder(ctx, order).Return(errors.New("db error"))
+		gomock.InOrder(
+			mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OrderOutcome(0), errors.New("db error")),
+			mockCache.EXPECT().Delete(gomock.Any(), "test-123").Return(false),
+		)
 
-		err := service.ProcessOrder(ctx, order)
+		_, err := service.ProcessOrder(ctx, order)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to save order to DB")
 	})
 }
 
+func TestOrderService_ProcessOrderReplacePolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockValidator := mocks.NewMockValidator(ctrl)
+	orderCache := cache.NewCache()
+
+	opts := DefaultOptions()
+	opts.ConflictPolicy = models.ConflictReplace
+	service := newOrderService(mockRepo, orderCache, opts)
+	service.validator = mockValidator
+
+	stale := &models.Order{OrderUID: "order-1", TrackNumber: "STALE"}
+	updated := &models.Order{OrderUID: "order-1", TrackNumber: "UPDATED"}
+	require.NoError(t, orderCache.Set(t.Context(), stale))
+
+	mockValidator.EXPECT().ValidateOrder(updated).Return(nil)
+	mockRepo.EXPECT().SaveOrder(gomock.Any(), updated, models.ConflictReplace).Return(models.OutcomeReplaced, nil)
+
+	outcome, err := service.ProcessOrder(t.Context(), updated)
+
+	require.NoError(t, err)
+	assert.Equal(t, models.OutcomeReplaced, outcome)
+	cached, exists, err := orderCache.Get(t.Context(), "order-1")
+	require.NoError(t, err)
+	require.True(t, exists)
+	assert.Equal(t, updated, cached)
+}
+
+func TestOrderService_ProcessOrderCacheConsistency(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockValidator := mocks.NewMockValidator(ctrl)
+	mockValidator.EXPECT().ValidateOrder(gomock.Any()).Return(nil).AnyTimes()
+	orderCache := cache.NewCache()
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     orderCache,
+		validator: mockValidator,
+	}
+
+	stale := &models.Order{OrderUID: "order-1", TrackNumber: "STALE"}
+	updated := &models.Order{OrderUID: "order-1", TrackNumber: "UPDATED"}
+
+	t.Run("failed save drops the cached version", func(t *testing.T) {
+		require.NoError(t, orderCache.Set(t.Context(), stale))
+		mockRepo.EXPECT().SaveOrder(gomock.Any(), updated, models.ConflictReject).Return(models.OrderOutcome(0), errors.New("db error"))
+
+		_, err := service.ProcessOrder(t.Context(), updated)
+
+		require.Error(t, err)
+
+		_, exists, err := orderCache.Get(t.Context(), "order-1")
+		require.NoError(t, err)
+		assert.False(t, exists, "cache must not serve an order the DB rejected")
+	})
+
+	t.Run("rejected conflict keeps the cached version", func(t *testing.T) {
+		require.NoError(t, orderCache.Set(t.Context(), stale))
+		mockRepo.EXPECT().SaveOrder(gomock.Any(), updated, models.ConflictReject).Return(models.OutcomeConflict, nil)
+
+		_, err := service.ProcessOrder(t.Context(), updated)
+		require.ErrorIs(t, err, models.ErrOrderConflict)
+
+		cached, exists, err := orderCache.Get(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, stale, cached)
+	})
+
+	t.Run("failed save of a new order is not cached", func(t *testing.T) {
+		order := &models.Order{OrderUID: "order-2"}
+		mockRepo.EXPECT().SaveOrder(gomock.Any(), order, models.ConflictReject).Return(models.OrderOutcome(0), errors.New("db error"))
+
+		_, err := service.ProcessOrder(t.Context(), order)
+
+		require.Error(t, err)
+
+		_, exists, err := orderCache.Get(t.Context(), "order-2")
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("cache is written only after commit", func(t *testing.T) {
+		require.NoError(t, orderCache.Set(t.Context(), stale))
+		mockRepo.EXPECT().SaveOrder(gomock.Any(), updated, models.ConflictReject).DoAndReturn(func(ctx context.Context, _ *models.Order, _ models.ConflictPolicy) (models.OrderOutcome, error) {
+			cached, _, _ := orderCache.Get(ctx, "order-1")
+			assert.Equal(t, stale, cached, "cache updated before the save committed")
+			return models.OutcomeReplaced, nil
+		})
+
+		_, err := service.ProcessOrder(t.Context(), updated)
+
+		require.NoError(t, err)
+
+		cached, exists, err := orderCache.Get(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, updated, cached)
+	})
+
+	t.Run("caller cancelling after commit still updates the cache", func(t *testing.T) {
+		require.NoError(t, orderCache.Set(t.Context(), stale))
+		ctx, cancel := context.WithCancel(t.Context())
+		mockRepo.EXPECT().SaveOrder(gomock.Any(), updated, models.ConflictReject).DoAndReturn(func(context.Context, *models.Order, models.ConflictPolicy) (models.OrderOutcome, error) {
+			cancel()
+			return models.OutcomeReplaced, nil
+		})
+
+		_, err := service.ProcessOrder(ctx, updated)
+
+		require.NoError(t, err)
+
+		cached, exists, err := orderCache.Get(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, updated, cached)
+	})
+}
+
 func TestOrderService_GetOrder(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -88,7 +281,7 @@ func TestOrderService_GetOrder(t *testing.T) {
 	order := &models.Order{OrderUID: "test-123"}
 
 	t.Run("from cache", func(t *testing.T) {
-		mockCache.EXPECT().Get("test-123").Return(order, true)
+		mockCache.EXPECT().Get(gomock.Any(), "test-123").Return(order, true, nil)
 
 		result, err := service.GetOrder(ctx, "test-123")
 
@@ -97,9 +290,9 @@ func TestOrderService_GetOrder(t *testing.T) {
 	})
 
 	t.Run("from repository", func(t *testing.T) {
-		mockCache.EXPECT().Get("test-123").Return((*models.Order)(nil), false)
-		mockRepo.EXPECT().GetOrderByUID(ctx, "test-123").Return(order, nil)
-		mockCache.EXPECT().Set(order).Return(nil)
+		mockCache.EXPECT().Get(gomock.Any(), "test-123").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "test-123").Return(order, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
 
 		result, err := service.GetOrder(ctx, "test-123")
 
@@ -107,6 +300,28 @@ func TestOrderService_GetOrder(t *testing.T) {
 		assert.Equal(t, order, result)
 	})
 
+	t.Run("cache error falls back to repository", func(t *testing.T) {
+		mockCache.EXPECT().Get(gomock.Any(), "test-123").Return((*models.Order)(nil), false, errors.New("connection refused"))
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "test-123").Return(order, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+
+		result, err := service.GetOrder(ctx, "test-123")
+
+		require.NoError(t, err)
+		assert.Equal(t, order, result)
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		mockCache.EXPECT().Get(gomock.Any(), "test-123").Return((*models.Order)(nil), false, context.Canceled)
+
+		result, err := service.GetOrder(cancelled, "test-123")
+
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, result)
+	})
+
 	t.Run("empty orderUID", func(t *testing.T) {
 		result, err := service.GetOrder(ctx, "")
 
@@ -116,8 +331,8 @@ func TestOrderService_GetOrder(t *testing.T) {
 	})
 
 	t.Run("repository error", func(t *testing.T) {
-		mockCache.EXPECT().Get("test-123").Return((*models.Order)(nil), false)
-		mockRepo.EXPECT().GetOrderByUID(ctx, "test-123").Return((*models.Order)(nil), errors.New("db error"))
+		mockCache.EXPECT().Get(gomock.Any(), "test-123").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "test-123").Return((*models.Order)(nil), errors.New("db error"))
 
 		result, err := service.GetOrder(ctx, "test-123")
 
@@ -127,7 +342,286 @@ func TestOrderService_GetOrder(t *testing.T) {
 	})
 }
 
-func TestOrderService_GetAllOrders(t *testing.T) {
+func TestOrderService_GetOrderCoalescesConcurrentMisses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     cache.NewCache(),
+		validator: &models.Validator{},
+	}
+
+	const callers = 50
+	order := &models.Order{OrderUID: "hot-order"}
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "hot-order").
+		DoAndReturn(func(ctx context.Context, orderUID string) (*models.Order, error) {
+			close(started)
+			<-release
+			return order, nil
+		}).Times(1)
+
+	var wg sync.WaitGroup
+	results := make(chan *models.Order, callers)
+	errs := make(chan error, callers)
+
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := service.GetOrder(context.Background(), "hot-order")
+			if err != nil {
+				errs <- err
+				return
+			}
+			results <- result
+		}()
+	}
+
+	<-started
+	close(release)
+	wg.Wait()
+	close(results)
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("unexpected error: %v", err)
+	}
+	count := 0
+	for result := range results {
+		assert.Same(t, order, result)
+		count++
+	}
+	assert.Equal(t, callers, count)
+}
+
+func TestOrderService_GetOrderRespectsCallerCancellation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     cache.NewCache(),
+		validator: &models.Validator{},
+	}
+
+	order := &models.Order{OrderUID: "slow-order"}
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "slow-order").
+		DoAndReturn(func(ctx context.Context, orderUID string) (*models.Order, error) {
+			close(started)
+			<-release
+			return order, ctx.Err()
+		}).Times(1)
+
+	leaderCtx, cancelLeader := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := service.GetOrder(leaderCtx, "slow-order")
+		leaderErr <- err
+	}()
+	<-started
+
+	followerResult := make(chan *models.Order, 1)
+	go func() {
+		result, err := service.GetOrder(context.Background(), "slow-order")
+		assert.NoError(t, err)
+		followerResult <- result
+	}()
+
+	cancelLeader()
+	require.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	close(release)
+	assert.Same(t, order, <-followerResult)
+}
+
+func TestOrderService_GetOrderLoadTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	opts := DefaultOptions()
+	opts.LoadTimeout = 20 * time.Millisecond
+	service := newOrderService(mockRepo, cache.NewCache(), opts)
+
+	order := &models.Order{OrderUID: "hung-order"}
+	gomock.InOrder(
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "hung-order").
+			DoAndReturn(func(ctx context.Context, _ string) (*models.Order, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			}),
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "hung-order").Return(order, nil),
+	)
+
+	_, err := service.GetOrder(context.Background(), "hung-order")
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	result, err := service.GetOrder(context.Background(), "hung-order")
+	require.NoError(t, err)
+	assert.Same(t, order, result)
+}
+
+func TestOrderService_NegativeCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockValidator := mocks.NewMockValidator(ctrl)
+
+	service := newOrderService(mockRepo, cache.NewCache(), DefaultOptions())
+	service.validator = mockValidator
+	ctx := context.Background()
+
+	t.Run("confirmed miss is remembered", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "missing").
+			Return((*models.Order)(nil), models.ErrOrderNotFound).Times(1)
+
+		for i := 0; i < 3; i++ {
+			result, err := service.GetOrder(ctx, "missing")
+			require.ErrorIs(t, err, models.ErrOrderNotFound)
+			assert.Nil(t, result)
+		}
+	})
+
+	t.Run("other errors are not remembered", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "flaky").
+			Return((*models.Order)(nil), errors.New("db error")).Times(2)
+
+		_, err := service.GetOrder(ctx, "flaky")
+		require.Error(t, err)
+		_, err = service.GetOrder(ctx, "flaky")
+		require.Error(t, err)
+	})
+
+	t.Run("processed order clears the miss", func(t *testing.T) {
+		order := &models.Order{OrderUID: "missing"}
+		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
+		mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeCreated, nil)
+
+		_, err := service.ProcessOrder(ctx, order)
+
+		require.NoError(t, err)
+
+		result, err := service.GetOrder(ctx, "missing")
+		require.NoError(t, err)
+		assert.Same(t, order, result)
+	})
+}
+
+func TestOrderService_MembershipFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+	mockValidator := mocks.NewMockValidator(ctrl)
+
+	opts := DefaultOptions()
+	opts.NegativeTTL = 0
+	opts.MembershipFilter = true
+
+	service := newOrderService(mockRepo, mockCache, opts)
+	service.validator = mockValidator
+	ctx := context.Background()
+
+	t.Run("filter is inactive before restore", func(t *testing.T) {
+		mockCache.EXPECT().Get(gomock.Any(), "never-seen").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "never-seen").
+			Return((*models.Order)(nil), models.ErrOrderNotFound)
+
+		_, err := service.GetOrder(ctx, "never-seen")
+		require.ErrorIs(t, err, models.ErrOrderNotFound)
+	})
+
+	known := &models.Order{OrderUID: "known"}
+	mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return([]string{"known"}, nil)
+	mockRepo.EXPECT().GetOrderByUID(ctx, "known").Return(known, nil)
+	mockCache.EXPECT().Set(gomock.Any(), known).Return(nil)
+	_, err := service.RestoreCacheFromDB(ctx, DefaultWarmUpOptions())
+	require.NoError(t, err)
+
+	t.Run("absent UID skips the DB", func(t *testing.T) {
+		mockCache.EXPECT().Get(gomock.Any(), "never-seen").Return((*models.Order)(nil), false, nil)
+
+		result, err := service.GetOrder(ctx, "never-seen")
+		require.ErrorIs(t, err, models.ErrOrderNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("known UID falls through to the DB", func(t *testing.T) {
+		mockCache.EXPECT().Get(gomock.Any(), "known").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "known").Return(known, nil)
+		mockCache.EXPECT().Set(gomock.Any(), known).Return(nil)
+
+		result, err := service.GetOrder(ctx, "known")
+		require.NoError(t, err)
+		assert.Same(t, known, result)
+	})
+
+	t.Run("processed order is added to the filter", func(t *testing.T) {
+		order := &models.Order{OrderUID: "fresh"}
+		mockValidator.EXPECT().ValidateOrder(order).Return(nil)
+		mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+		mockRepo.EXPECT().SaveOrder(ctx, order, models.ConflictReject).Return(models.OutcomeCreated, nil)
+		_, err := service.ProcessOrder(ctx, order)
+		require.NoError(t, err)
+
+		mockCache.EXPECT().Get(gomock.Any(), "fresh").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "fresh").Return(order, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+
+		result, err := service.GetOrder(ctx, "fresh")
+		require.NoError(t, err)
+		assert.Same(t, order, result)
+	})
+}
+
+func TestOptions_Validate(t *testing.T) {
+	require.NoError(t, DefaultOptions().Validate())
+
+	opts := DefaultOptions()
+	opts.NegativeTTL = -time.Second
+	assert.Error(t, opts.Validate())
+
+	opts = DefaultOptions()
+	opts.NegativeMaxEntries = 0
+	assert.Error(t, opts.Validate())
+
+	opts = DefaultOptions()
+	opts.MembershipFilter = true
+	opts.FilterFalsePositiveRate = 1
+	assert.Error(t, opts.Validate())
+
+	policyOpts := DefaultOptions()
+	policyOpts.ConflictPolicy = models.ConflictPolicy(99)
+	assert.Error(t, policyOpts.Validate())
+
+	timeoutOpts := DefaultOptions()
+	timeoutOpts.LoadTimeout = 0
+	assert.Error(t, timeoutOpts.Validate())
+
+	snapshotOpts := DefaultOptions()
+	snapshotOpts.SnapshotPath = "cache.snapshot"
+	snapshotOpts.SnapshotMaxAge = 0
+	assert.Error(t, snapshotOpts.Validate())
+
+	_, err := NewOrderServiceWithOptions(nil, nil, opts)
+	assert.Error(t, err)
+}
+
+func TestOrderService_GetOrdersByTrackNumber(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -140,16 +634,183 @@ func TestOrderService_GetAllOrders(t *testing.T) {
 		validator: &models.Validator{},
 	}
 
-	orders := []*models.Order{
-		{OrderUID: "order-1"},
-		{OrderUID: "order-2"},
+	ctx := context.Background()
+	order := &models.Order{OrderUID: "order-1", TrackNumber: "WB1"}
+	cached := &models.Order{OrderUID: "order-2", TrackNumber: "WB1"}
+
+	t.Run("cached and uncached matches", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderUIDsByTrackNumber(ctx, "WB1").Return([]string{"order-1", "order-2"}, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-1").Return((*models.Order)(nil), false, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-2").Return(cached, true, nil)
+		mockRepo.EXPECT().GetOrdersByUIDs(gomock.Any(), []string{"order-1"}).Return([]*models.Order{order}, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+
+		result, err := service.GetOrdersByTrackNumber(ctx, "WB1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Order{order, cached}, result)
+	})
+
+	t.Run("misses loaded in one batch", func(t *testing.T) {
+		other := &models.Order{OrderUID: "order-3", TrackNumber: "WB1"}
+		mockRepo.EXPECT().GetOrderUIDsByTrackNumber(ctx, "WB1").Return([]string{"order-1", "order-3"}, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-1").Return((*models.Order)(nil), false, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-3").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrdersByUIDs(gomock.Any(), []string{"order-1", "order-3"}).
+			Return([]*models.Order{other, order}, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+		mockCache.EXPECT().Set(gomock.Any(), other).Return(nil)
+
+		result, err := service.GetOrdersByTrackNumber(ctx, "WB1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Order{order, other}, result)
+	})
+
+	t.Run("order deleted after the search", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderUIDsByTrackNumber(ctx, "WB1").Return([]string{"order-1"}, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-1").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrdersByUIDs(gomock.Any(), []string{"order-1"}).Return(nil, nil)
+
+		result, err := service.GetOrdersByTrackNumber(ctx, "WB1")
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("no orders", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderUIDsByTrackNumber(ctx, "WB0").Return(nil, nil)
+
+		result, err := service.GetOrdersByTrackNumber(ctx, "WB0")
+
+		require.NoError(t, err)
+		assert.Empty(t, result)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderUIDsByTrackNumber(ctx, "WB1").Return(nil, errors.New("db error"))
+
+		_, err := service.GetOrdersByTrackNumber(ctx, "WB1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to find orders by track number")
+	})
+
+	t.Run("empty track number", func(t *testing.T) {
+		_, err := service.GetOrdersByTrackNumber(ctx, "")
+		require.Error(t, err)
+	})
+}
+
+func TestOrderService_GetOrdersByCustomerID(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     mockCache,
+		validator: &models.Validator{},
 	}
 
-	mockCache.EXPECT().GetAll().Return(orders)
+	ctx := context.Background()
+	order1 := &models.Order{OrderUID: "order-1", CustomerID: "alice"}
+	order2 := &models.Order{OrderUID: "order-2", CustomerID: "alice"}
+
+	t.Run("cached and uncached matches", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderUIDsByCustomerID(ctx, "alice").Return([]string{"order-1", "order-2"}, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-1").Return(order1, true, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-2").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrdersByUIDs(gomock.Any(), []string{"order-2"}).Return([]*models.Order{order2}, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order2).Return(nil)
+
+		result, err := service.GetOrdersByCustomerID(ctx, "alice")
 
-	result := service.GetAllOrders()
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Order{order1, order2}, result)
+	})
+
+	t.Run("order load error", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderUIDsByCustomerID(ctx, "alice").Return([]string{"order-1"}, nil)
+		mockCache.EXPECT().Get(gomock.Any(), "order-1").Return((*models.Order)(nil), false, nil)
+		mockRepo.EXPECT().GetOrdersByUIDs(gomock.Any(), []string{"order-1"}).Return(nil, errors.New("db error"))
 
-	assert.Equal(t, orders, result)
+		_, err := service.GetOrdersByCustomerID(ctx, "alice")
+
+		require.Error(t, err)
+	})
+}
+
+func TestOrderService_GetOrdersIgnoresNegativeCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     mockCache,
+		validator: &models.Validator{},
+		negative:  cache.NewNegativeCache(time.Minute, 10, nil),
+	}
+	service.filter.Store(bloom.New(16, 0.01))
+	service.negative.Add("order-1")
+
+	ctx := context.Background()
+	order := &models.Order{OrderUID: "order-1", CustomerID: "alice"}
+
+	mockRepo.EXPECT().GetOrderUIDsByCustomerID(ctx, "alice").Return([]string{"order-1"}, nil)
+	mockCache.EXPECT().Get(gomock.Any(), "order-1").Return((*models.Order)(nil), false, nil)
+	mockRepo.EXPECT().GetOrdersByUIDs(gomock.Any(), []string{"order-1"}).Return([]*models.Order{order}, nil)
+	mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+
+	result, err := service.GetOrdersByCustomerID(ctx, "alice")
+
+	require.NoError(t, err)
+	assert.Equal(t, []*models.Order{order}, result)
+	assert.False(t, service.negative.Contains("order-1"))
+	assert.False(t, service.isKnownMissing("order-1"))
+}
+
+func TestOrderService_ListOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     mockCache,
+		validator: &models.Validator{},
+	}
+
+	ctx := context.Background()
+
+	t.Run("returns cache page", func(t *testing.T) {
+		page := interfaces.OrderPage{
+			Orders:     []*models.Order{{OrderUID: "order-1"}, {OrderUID: "order-2"}},
+			NextCursor: "next",
+		}
+		mockCache.EXPECT().List(gomock.Any(), "", 2).Return(page, nil)
+
+		result, err := service.ListOrders(ctx, "", 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, page, result)
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		mockCache.EXPECT().List(gomock.Any(), "bad", 2).Return(interfaces.OrderPage{}, errors.New("invalid cursor"))
+
+		_, err := service.ListOrders(ctx, "bad", 2)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list orders")
+	})
 }
 
 func TestOrderService_RestoreCacheFromDB(t *testing.T) {
@@ -175,18 +836,22 @@ func TestOrderService_RestoreCacheFromDB(t *testing.T) {
 		mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return(orderUIDs, nil)
 		mockRepo.EXPECT().GetOrderByUID(ctx, "order-1").Return(order1, nil)
 		mockRepo.EXPECT().GetOrderByUID(ctx, "order-2").Return(order2, nil)
-		mockCache.EXPECT().Set(order1).Return(nil)
-		mockCache.EXPECT().Set(order2).Return(nil)
+		mockCache.EXPECT().Set(gomock.Any(), order1).Return(nil)
+		mockCache.EXPECT().Set(gomock.Any(), order2).Return(nil)
 
-		err := service.RestoreCacheFromDB(ctx)
+		warmUp, err := service.RestoreCacheFromDB(ctx, DefaultWarmUpOptions())
 
 		require.NoError(t, err)
+		progress := warmUp.Progress()
+		assert.Equal(t, 2, progress.Total)
+		assert.Equal(t, 2, progress.Loaded)
+		assert.True(t, progress.Done)
 	})
 
 	t.Run("error getting order uids", func(t *testing.T) {
 		mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return(nil, errors.New("db error"))
 
-		err := service.RestoreCacheFromDB(ctx)
+		_, err := service.RestoreCacheFromDB(ctx, DefaultWarmUpOptions())
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get orderUID for cache restoration")
@@ -199,11 +864,13 @@ func TestOrderService_RestoreCacheFromDB(t *testing.T) {
 		mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return(orderUIDs, nil)
 		mockRepo.EXPECT().GetOrderByUID(ctx, "order-1").Return((*models.Order)(nil), errors.New("db error"))
 		mockRepo.EXPECT().GetOrderByUID(ctx, "order-2").Return(order2, nil)
-		mockCache.EXPECT().Set(order2).Return(nil)
+		mockCache.EXPECT().Set(gomock.Any(), order2).Return(nil)
 
-		err := service.RestoreCacheFromDB(ctx)
+		warmUp, err := service.RestoreCacheFromDB(ctx, DefaultWarmUpOptions())
 
 		require.NoError(t, err)
+		assert.Equal(t, 1, warmUp.Progress().Loaded)
+		assert.Equal(t, 1, warmUp.Progress().Failed)
 	})
 
 	t.Run("error caching order", func(t *testing.T) {
@@ -214,11 +881,125 @@ func TestOrderService_RestoreCacheFromDB(t *testing.T) {
 		mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return(orderUIDs, nil)
 		mockRepo.EXPECT().GetOrderByUID(ctx, "order-1").Return(order1, nil)
 		mockRepo.EXPECT().GetOrderByUID(ctx, "order-2").Return(order2, nil)
-		mockCache.EXPECT().Set(order1).Return(errors.New("cache error"))
-		mockCache.EXPECT().Set(order2).Return(nil)
+		mockCache.EXPECT().Set(gomock.Any(), order1).Return(errors.New("cache error"))
+		mockCache.EXPECT().Set(gomock.Any(), order2).Return(nil)
 
-		err := service.RestoreCacheFromDB(ctx)
+		warmUp, err := service.RestoreCacheFromDB(ctx, DefaultWarmUpOptions())
 
 		require.NoError(t, err)
+		assert.Equal(t, 1, warmUp.Progress().Loaded)
+		assert.Equal(t, 1, warmUp.Progress().Failed)
+	})
+}
+
+func TestOrderService_CacheStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		cache:     mockCache,
+		validator: &models.Validator{},
+	}
+
+	stats := interfaces.CacheStats{Hits: 3, Misses: 1, Evictions: 2, Size: 5, MemoryBytes: 1024}
+	mockCache.EXPECT().Stats().Return(stats)
+
+	assert.Equal(t, stats, service.CacheStats())
+}
+
+func TestOrderService_Invalidate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		cache:     mockCache,
+		validator: &models.Validator{},
+		negative:  cache.NewNegativeCache(time.Minute, 10, nil),
+	}
+	service.negative.Add("missing-1")
+	service.negative.Add("missing-2")
+
+	ctx := context.Background()
+
+	mockCache.EXPECT().Delete(gomock.Any(), "missing-1").Return(false)
+	assert.False(t, service.InvalidateOrder(ctx, "missing-1"))
+	assert.False(t, service.negative.Contains("missing-1"))
+
+	mockCache.EXPECT().DeleteMany(gomock.Any(), []string{"order-1", "order-2"}).Return(2)
+	assert.Equal(t, 2, service.InvalidateOrders(ctx, []string{"order-1", "order-2"}))
+
+	mockCache.EXPECT().Purge(gomock.Any()).Return(5)
+	assert.Equal(t, 5, service.PurgeCache(ctx))
+	assert.Equal(t, 0, service.negative.Size())
+}
+
+func TestOrderService_ResyncCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	opts := DefaultOptions()
+	opts.MembershipFilter = true
+	service := newOrderService(mockRepo, mockCache, opts)
+	service.buildFilter([]string{"order-1"})
+	service.negative.Add("missing")
+
+	ctx := context.Background()
+
+	t.Run("rebuilds filter", func(t *testing.T) {
+		mockCache.EXPECT().Purge(ctx).Return(3)
+		mockRepo.EXPECT().GetAllOrderUIDs(ctx).DoAndReturn(func(context.Context) ([]string, error) {
+			// An order stored while the DB is read must not be lost.
+			service.markKnown("order-3")
+			return []string{"order-1", "order-2"}, nil
+		})
+
+		service.ResyncCache(ctx)
+
+		assert.Equal(t, 0, service.negative.Size())
+		require.NotNil(t, service.filter.Load())
+		for _, uid := range []string{"order-1", "order-2", "order-3"} {
+			assert.False(t, service.isKnownMissing(uid), uid)
+		}
+		assert.Empty(t, service.pendingUIDs)
 	})
+
+	t.Run("drops filter when rebuild fails", func(t *testing.T) {
+		mockCache.EXPECT().Purge(ctx).Return(0)
+		mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return(nil, errors.New("db error"))
+
+		service.ResyncCache(ctx)
+
+		assert.Nil(t, service.filter.Load())
+		assert.False(t, service.isKnownMissing("never-seen"))
+	})
+}
+
+func TestOrderService_ApplyOrderChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		cache:     mockCache,
+		validator: &models.Validator{},
+		negative:  cache.NewNegativeCache(time.Minute, 10, nil),
+	}
+	service.filter.Store(bloom.New(16, 0.01))
+	service.negative.Add("order-1")
+
+	ctx := context.Background()
+
+	// No DB load: the next read fetches the order if anyone asks for it.
+	mockCache.EXPECT().Delete(ctx, "order-1").Return(true)
+	service.ApplyOrderChange(ctx, "order-1")
+
+	assert.False(t, service.isKnownMissing("order-1"))
 }
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"L0/internal/interfaces"
+	"L0/internal/snapshot"
+)
+
+var ErrStaleSnapshot = errors.New("snapshot is stale")
+
+// RestoreCache fills the cache from the snapshot file when one is configured
+// and still matches the database, and from the database otherwise. A
+// snapshot is always loaded in the foreground; opts apply to the database.
+func (s *OrderService) RestoreCache(ctx context.Context, opts interfaces.WarmUpOptions) (interfaces.WarmUp, error) {
+	if s.opts.SnapshotPath != "" {
+		w, err := s.restoreFromSnapshot(ctx)
+		if err == nil {
+			log.Printf("Cache restored from snapshot. Loaded %d orders", w.Progress().Loaded)
+			return w, nil
+		}
+		log.Printf("Cache snapshot not used, falling back to database: %v", err)
+	}
+
+	return s.RestoreCacheFromDB(ctx, opts)
+}
+
+func (s *OrderService) restoreFromSnapshot(ctx context.Context) (*warmUp, error) {
+	snap, err := snapshot.Load(s.opts.SnapshotPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if age := time.Since(snap.CreatedAt); age > s.opts.SnapshotMaxAge {
+		return nil, fmt.Errorf("snapshot is %s old, max age is %s: %w", age.Round(time.Second), s.opts.SnapshotMaxAge, ErrStaleSnapshot)
+	}
+
+	summary, err := s.orderRepo.GetOrdersSummary(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check snapshot against database: %w", err)
+	}
+	if !summary.Equal(snap.Summary) {
+		return nil, fmt.Errorf("database has %d orders up to %s at revision sum %d, snapshot was taken at %d up to %s at %d: %w",
+			summary.Count, summary.LatestDateCreated.Format(time.RFC3339), summary.RevisionSum,
+			snap.Summary.Count, snap.Summary.LatestDateCreated.Format(time.RFC3339), snap.Summary.RevisionSum, ErrStaleSnapshot)
+	}
+
+	if s.opts.MembershipFilter {
+		orderUIDs, err := s.orderRepo.GetAllOrderUIDs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get orderUID for membership filter: %w", err)
+		}
+		s.buildFilter(orderUIDs)
+	}
+
+	// Orders are stored newest first; insert oldest first so the newest end
+	// up as the most recently used.
+	w := newWarmUp(len(snap.Orders))
+	for i := len(snap.Orders) - 1; i >= 0; i-- {
+		if err := s.cache.Set(ctx, snap.Orders[i]); err != nil {
+			log.Printf("Failed to cache order %s from snapshot: %v", snap.Orders[i].OrderUID, err)
+			w.failed.Add(1)
+			continue
+		}
+		w.loaded.Add(1)
+	}
+	w.finish()
+
+	return w, nil
+}
+
+// SaveCacheSnapshot writes the cached orders to the snapshot file. It does
+// nothing when snapshots are disabled.
+func (s *OrderService) SaveCacheSnapshot(ctx context.Context) error {
+	if s.opts.SnapshotPath == "" {
+		return nil
+	}
+
+	// Read the summary before the orders: an order saved in between makes
+	// the snapshot look stale, never fresher than it is.
+	summary, err := s.orderRepo.GetOrdersSummary(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get orders summary for snapshot: %w", err)
+	}
+
+	orders, err := s.cache.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read cache for snapshot: %w", err)
+	}
+
+	snap := &snapshot.Snapshot{
+		CreatedAt: time.Now(),
+		Summary:   summary,
+		Orders:    orders,
+	}
+	if err := snapshot.Save(s.opts.SnapshotPath, snap); err != nil {
+		return err
+	}
+
+	log.Printf("Cache snapshot saved. Stored %d orders", len(snap.Orders))
+	return nil
+}
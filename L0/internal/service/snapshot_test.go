@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"L0/internal/cache"
+	"L0/internal/mocks"
+	"L0/internal/models"
+	"L0/internal/snapshot"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderService_RestoreCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+	opts := DefaultOptions()
+	opts.SnapshotPath = path
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     mockCache,
+		validator: &models.Validator{},
+		opts:      opts,
+	}
+
+	ctx := context.Background()
+	summary := models.OrdersSummary{Count: 2, LatestDateCreated: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), RevisionSum: 3}
+	order1 := &models.Order{OrderUID: "order-1"}
+	order2 := &models.Order{OrderUID: "order-2"}
+
+	expectDBRestore := func() {
+		mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return([]string{"order-1"}, nil)
+		mockRepo.EXPECT().GetOrderByUID(ctx, "order-1").Return(order1, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order1).Return(nil)
+	}
+
+	t.Run("missing snapshot falls back to database", func(t *testing.T) {
+		expectDBRestore()
+
+		_, err := service.RestoreCache(ctx, DefaultWarmUpOptions())
+		require.NoError(t, err)
+	})
+
+	t.Run("fresh snapshot skips per-order queries", func(t *testing.T) {
+		require.NoError(t, snapshot.Save(path, &snapshot.Snapshot{
+			CreatedAt: time.Now(),
+			Summary:   summary,
+			Orders:    []*models.Order{order2, order1},
+		}))
+
+		mockRepo.EXPECT().GetOrdersSummary(ctx).Return(summary, nil)
+		gomock.InOrder(
+			mockCache.EXPECT().Set(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) error {
+				assert.Equal(t, "order-1", order.OrderUID)
+				return nil
+			}),
+			mockCache.EXPECT().Set(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, order *models.Order) error {
+				assert.Equal(t, "order-2", order.OrderUID)
+				return nil
+			}),
+		)
+
+		warmUp, err := service.RestoreCache(ctx, DefaultWarmUpOptions())
+		require.NoError(t, err)
+		assert.Equal(t, 2, warmUp.Progress().Loaded)
+	})
+
+	t.Run("database changed since snapshot", func(t *testing.T) {
+		changed := summary
+		changed.Count++
+		mockRepo.EXPECT().GetOrdersSummary(ctx).Return(changed, nil)
+		expectDBRestore()
+
+		_, err := service.RestoreCache(ctx, DefaultWarmUpOptions())
+		require.NoError(t, err)
+	})
+
+	t.Run("order updated in place since snapshot", func(t *testing.T) {
+		require.NoError(t, snapshot.Save(path, &snapshot.Snapshot{
+			CreatedAt: time.Now(),
+			Summary:   summary,
+			Orders:    []*models.Order{order2, order1},
+		}))
+
+		updated := summary
+		updated.RevisionSum += 2
+		mockRepo.EXPECT().GetOrdersSummary(ctx).Return(updated, nil).Times(2)
+		expectDBRestore()
+
+		_, err := service.restoreFromSnapshot(ctx)
+		assert.ErrorIs(t, err, ErrStaleSnapshot)
+		_, err = service.RestoreCache(ctx, DefaultWarmUpOptions())
+		require.NoError(t, err)
+	})
+
+	t.Run("snapshot too old", func(t *testing.T) {
+		require.NoError(t, snapshot.Save(path, &snapshot.Snapshot{
+			CreatedAt: time.Now().Add(-2 * opts.SnapshotMaxAge),
+			Summary:   summary,
+		}))
+		expectDBRestore()
+
+		_, err := service.restoreFromSnapshot(ctx)
+		assert.ErrorIs(t, err, ErrStaleSnapshot)
+		_, err = service.RestoreCache(ctx, DefaultWarmUpOptions())
+		require.NoError(t, err)
+	})
+
+	t.Run("corrupt snapshot", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte("not a snapshot"), 0o644))
+		expectDBRestore()
+
+		_, err := service.RestoreCache(ctx, DefaultWarmUpOptions())
+		require.NoError(t, err)
+	})
+}
+
+func TestOrderService_SaveCacheSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	orderCache := cache.NewCache()
+
+	path := filepath.Join(t.TempDir(), "cache.snapshot")
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     orderCache,
+		validator: &models.Validator{},
+	}
+	ctx := context.Background()
+
+	require.NoError(t, service.SaveCacheSnapshot(ctx), "disabled snapshots are a no-op")
+	_, err := os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	service.opts.SnapshotPath = path
+	summary := models.OrdersSummary{Count: 1, LatestDateCreated: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)}
+	mockRepo.EXPECT().GetOrdersSummary(ctx).Return(summary, nil)
+	require.NoError(t, orderCache.Set(t.Context(), &models.Order{OrderUID: "order-1"}))
+
+	require.NoError(t, service.SaveCacheSnapshot(ctx))
+
+	snap, err := snapshot.Load(path)
+	require.NoError(t, err)
+	assert.True(t, summary.Equal(snap.Summary))
+	require.Len(t, snap.Orders, 1)
+	assert.Equal(t, "order-1", snap.Orders[0].OrderUID)
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"L0/internal/models"
+)
+
+func (s *OrderService) ChangeOrderStatus(ctx context.Context, orderUID string, status models.OrderStatus, source string) (*models.Order, error) {
+	if orderUID == "" {
+		return nil, fmt.Errorf("orderUID cannot be empty")
+	}
+	if !status.Valid() {
+		return nil, fmt.Errorf("unknown order status %q", status)
+	}
+	if source == "" {
+		return nil, fmt.Errorf("status source cannot be empty")
+	}
+
+	// Check the transition against the committed status, not a cached one.
+	order, err := s.orderRepo.GetOrderByUID(ctx, orderUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order %s: %w", orderUID, err)
+	}
+	if !order.Status.CanTransitionTo(status) {
+		return nil, fmt.Errorf("order %s cannot move from %s to %s: %w", orderUID, order.Status, status, models.ErrInvalidStatusTransition)
+	}
+
+	change, err := s.orderRepo.UpdateOrderStatus(ctx, orderUID, order.Status, status, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to change status of order %s: %w", orderUID, err)
+	}
+	order.Status = change.Status
+	order.StatusHistory = append(order.StatusHistory, change)
+
+	cacheCtx := context.WithoutCancel(ctx)
+	if err := s.cache.Set(cacheCtx, order); err != nil {
+		s.cache.Delete(cacheCtx, orderUID)
+		log.Printf("Warning: failed to cache order %s: %v", orderUID, err)
+	}
+
+	log.Printf("Order %s status changed to %s by %s", orderUID, status, source)
+	return order, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"L0/internal/cache"
+	"L0/internal/mocks"
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderService_ChangeOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	orderCache := cache.NewCache()
+	service := newOrderService(mockRepo, orderCache, DefaultOptions())
+
+	created := models.StatusChange{Status: models.StatusCreated, ChangedAt: time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC), Source: models.StatusSourceIngestion}
+	storedOrder := func(status models.OrderStatus) *models.Order {
+		return &models.Order{
+			OrderUID:      "order-1",
+			Version:       1,
+			Status:        status,
+			StatusHistory: []models.StatusChange{created},
+		}
+	}
+
+	t.Run("allowed transition", func(t *testing.T) {
+		paid := models.StatusChange{Status: models.StatusPaid, ChangedAt: created.ChangedAt.Add(time.Hour), Source: "billing"}
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "order-1").Return(storedOrder(models.StatusCreated), nil)
+		mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), "order-1", models.StatusCreated, models.StatusPaid, "billing").Return(paid, nil)
+
+		order, err := service.ChangeOrderStatus(t.Context(), "order-1", models.StatusPaid, "billing")
+
+		require.NoError(t, err)
+		assert.Equal(t, models.StatusPaid, order.Status)
+		assert.Equal(t, []models.StatusChange{created, paid}, order.StatusHistory)
+
+		cached, exists, err := orderCache.Get(t.Context(), "order-1")
+		require.NoError(t, err)
+		require.True(t, exists)
+		assert.Equal(t, models.StatusPaid, cached.Status)
+	})
+
+	t.Run("illegal transition is rejected", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "order-1").Return(storedOrder(models.StatusCancelled), nil)
+
+		_, err := service.ChangeOrderStatus(t.Context(), "order-1", models.StatusShipped, "warehouse")
+
+		require.ErrorIs(t, err, models.ErrInvalidStatusTransition)
+	})
+
+	t.Run("lost race is reported", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "order-1").Return(storedOrder(models.StatusPaid), nil)
+		mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), "order-1", models.StatusPaid, models.StatusAssembling, "warehouse").
+			Return(models.StatusChange{}, models.ErrStatusChanged)
+
+		_, err := service.ChangeOrderStatus(t.Context(), "order-1", models.StatusAssembling, "warehouse")
+
+		require.ErrorIs(t, err, models.ErrStatusChanged)
+	})
+
+	t.Run("unknown order", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "missing").Return(nil, models.ErrOrderNotFound)
+
+		_, err := service.ChangeOrderStatus(t.Context(), "missing", models.StatusPaid, "billing")
+
+		require.ErrorIs(t, err, models.ErrOrderNotFound)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := service.ChangeOrderStatus(t.Context(), "", models.StatusPaid, "billing")
+		assert.Error(t, err)
+
+		_, err = service.ChangeOrderStatus(t.Context(), "order-1", models.OrderStatus("lost"), "billing")
+		assert.Error(t, err)
+
+		_, err = service.ChangeOrderStatus(t.Context(), "order-1", models.StatusPaid, "")
+		assert.Error(t, err)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockRepo.EXPECT().GetOrderByUID(gomock.Any(), "order-1").Return(storedOrder(models.StatusCreated), nil)
+		mockRepo.EXPECT().UpdateOrderStatus(gomock.Any(), "order-1", models.StatusCreated, models.StatusCancelled, "support").
+			Return(models.StatusChange{}, errors.New("db error"))
+
+		_, err := service.ChangeOrderStatus(t.Context(), "order-1", models.StatusCancelled, "support")
+
+		require.Error(t, err)
+	})
+}
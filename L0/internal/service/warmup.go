@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"L0/internal/interfaces"
+)
+
+const defaultWarmUpParallelism = 4
+
+func DefaultWarmUpOptions() interfaces.WarmUpOptions {
+	return interfaces.WarmUpOptions{Parallelism: defaultWarmUpParallelism}
+}
+
+func validateWarmUpOptions(opts interfaces.WarmUpOptions) error {
+	if opts.Limit < 0 {
+		return fmt.Errorf("warm-up limit cannot be negative, got %d", opts.Limit)
+	}
+
+	if opts.MaxAge < 0 {
+		return fmt.Errorf("warm-up max age cannot be negative, got %s", opts.MaxAge)
+	}
+
+	if opts.Parallelism < 0 {
+		return fmt.Errorf("warm-up parallelism cannot be negative, got %d", opts.Parallelism)
+	}
+
+	return nil
+}
+
+var _ interfaces.WarmUp = (*warmUp)(nil)
+
+type warmUp struct {
+	total   int
+	started time.Time
+	loaded  atomic.Int64
+	failed  atomic.Int64
+	elapsed atomic.Int64
+	done    chan struct{}
+}
+
+func newWarmUp(total int) *warmUp {
+	return &warmUp{total: total, started: time.Now(), done: make(chan struct{})}
+}
+
+func (w *warmUp) finish() {
+	w.elapsed.Store(int64(time.Since(w.started)))
+	close(w.done)
+}
+
+func (w *warmUp) Progress() interfaces.WarmUpProgress {
+	progress := interfaces.WarmUpProgress{
+		Total:  w.total,
+		Loaded: int(w.loaded.Load()),
+		Failed: int(w.failed.Load()),
+	}
+
+	select {
+	case <-w.done:
+		progress.Done = true
+		progress.Elapsed = time.Duration(w.elapsed.Load())
+	default:
+		progress.Elapsed = time.Since(w.started)
+	}
+	return progress
+}
+
+func (w *warmUp) Wait(ctx context.Context) interfaces.WarmUpProgress {
+	select {
+	case <-w.done:
+	case <-ctx.Done():
+	}
+	return w.Progress()
+}
+
+// loadIntoCache fetches orderUIDs with up to parallelism concurrent queries
+// and caches them, counting results in w. It stops early when ctx ends.
+func (s *OrderService) loadIntoCache(ctx context.Context, orderUIDs []string, parallelism int, w *warmUp) {
+	uids := make(chan string)
+	var wg sync.WaitGroup
+
+	for range min(parallelism, max(len(orderUIDs), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for orderUID := range uids {
+				if ctx.Err() != nil {
+					return
+				}
+
+				order, err := s.orderRepo.GetOrderByUID(ctx, orderUID)
+				if err != nil {
+					log.Printf("Error restoring order %s: %v", orderUID, err)
+					w.failed.Add(1)
+					continue
+				}
+
+				if err := s.cache.Set(ctx, order); err != nil {
+					log.Printf("Failed to cache order %s: %v", orderUID, err)
+					w.failed.Add(1)
+					continue
+				}
+				w.loaded.Add(1)
+			}
+		}()
+	}
+
+	defer wg.Wait()
+	defer close(uids)
+	for _, orderUID := range orderUIDs {
+		select {
+		case uids <- orderUID:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
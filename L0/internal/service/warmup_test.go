@@ -0,0 +1,230 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"L0/internal/cache"
+	"L0/internal/interfaces"
+	"L0/internal/mocks"
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOrderService_RestoreCacheFromDBBounded(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     mockCache,
+		validator: &models.Validator{},
+	}
+	ctx := context.Background()
+
+	t.Run("limit", func(t *testing.T) {
+		order := &models.Order{OrderUID: "order-1"}
+		mockRepo.EXPECT().GetRecentOrderUIDs(ctx, 1, time.Time{}).Return([]string{"order-1"}, nil)
+		mockRepo.EXPECT().GetOrderByUID(ctx, "order-1").Return(order, nil)
+		mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+
+		warmUp, err := service.RestoreCacheFromDB(ctx, interfaces.WarmUpOptions{Limit: 1})
+		require.NoError(t, err)
+		assert.Equal(t, interfaces.WarmUpProgress{Total: 1, Loaded: 1, Done: true}, withoutElapsed(warmUp.Progress()))
+	})
+
+	t.Run("max age", func(t *testing.T) {
+		before := time.Now()
+		mockRepo.EXPECT().GetRecentOrderUIDs(ctx, 0, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int, since time.Time) ([]string, error) {
+				assert.WithinRange(t, since, before.Add(-48*time.Hour), time.Now().Add(-48*time.Hour))
+				return nil, nil
+			})
+
+		warmUp, err := service.RestoreCacheFromDB(ctx, interfaces.WarmUpOptions{MaxAge: 48 * time.Hour})
+		require.NoError(t, err)
+		assert.Equal(t, 0, warmUp.Progress().Total)
+		assert.True(t, warmUp.Progress().Done)
+	})
+
+	t.Run("membership filter still sees every UID", func(t *testing.T) {
+		service.opts.MembershipFilter = true
+		service.opts.FilterFalsePositiveRate = 0.01
+		defer func() { service.opts.MembershipFilter = false }()
+
+		mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return([]string{"order-1", "order-2"}, nil)
+		mockRepo.EXPECT().GetRecentOrderUIDs(ctx, 1, time.Time{}).Return(nil, nil)
+
+		_, err := service.RestoreCacheFromDB(ctx, interfaces.WarmUpOptions{Limit: 1})
+		require.NoError(t, err)
+		assert.True(t, service.filter.Load().MayContain("order-2"))
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := service.RestoreCacheFromDB(ctx, interfaces.WarmUpOptions{Limit: -1})
+		assert.Error(t, err)
+	})
+}
+
+func TestOrderService_RestoreCacheFromDBParallelism(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     mockCache,
+		validator: &models.Validator{},
+	}
+	ctx := context.Background()
+
+	orderUIDs := []string{"order-1", "order-2", "order-3", "order-4", "order-5", "order-6"}
+	var inFlight, peak atomic.Int32
+
+	mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return(orderUIDs, nil)
+	mockRepo.EXPECT().GetOrderByUID(ctx, gomock.Any()).Times(len(orderUIDs)).
+		DoAndReturn(func(_ context.Context, orderUID string) (*models.Order, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return &models.Order{OrderUID: orderUID}, nil
+		})
+	mockCache.EXPECT().Set(gomock.Any(), gomock.Any()).Times(len(orderUIDs)).Return(nil)
+
+	warmUp, err := service.RestoreCacheFromDB(ctx, interfaces.WarmUpOptions{Parallelism: 2})
+	require.NoError(t, err)
+
+	assert.Equal(t, len(orderUIDs), warmUp.Progress().Loaded)
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+}
+
+// exclusiveRepository fails a call that overlaps another one, like a single
+// DB connection would.
+type exclusiveRepository struct {
+	interfaces.Repository
+	busy atomic.Bool
+}
+
+func (r *exclusiveRepository) GetOrderByUID(_ context.Context, orderUID string) (*models.Order, error) {
+	if !r.busy.CompareAndSwap(false, true) {
+		return nil, errors.New("conn busy")
+	}
+	defer r.busy.Store(false)
+
+	time.Sleep(time.Millisecond)
+	return &models.Order{OrderUID: orderUID}, nil
+}
+
+func TestOrderService_RestoreCacheFromDBSerial(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	orderUIDs := []string{"order-1", "order-2", "order-3", "order-4"}
+	mockRepo.EXPECT().GetAllOrderUIDs(gomock.Any()).Return(orderUIDs, nil)
+
+	service := &OrderService{
+		orderRepo: &exclusiveRepository{Repository: mockRepo},
+		cache:     cache.NewCache(),
+		validator: &models.Validator{},
+	}
+
+	warmUp, err := service.RestoreCacheFromDB(t.Context(), interfaces.WarmUpOptions{Parallelism: 1})
+	require.NoError(t, err)
+
+	assert.Equal(t, interfaces.WarmUpProgress{Total: len(orderUIDs), Loaded: len(orderUIDs), Done: true}, withoutElapsed(warmUp.Progress()))
+}
+
+func TestOrderService_RestoreCacheFromDBBackground(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+	mockCache := mocks.NewMockCache(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		cache:     mockCache,
+		validator: &models.Validator{},
+	}
+	ctx := context.Background()
+
+	release := make(chan struct{})
+	order := &models.Order{OrderUID: "order-1"}
+	mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return([]string{"order-1", "order-2"}, nil)
+	mockRepo.EXPECT().GetOrderByUID(ctx, "order-1").DoAndReturn(func(context.Context, string) (*models.Order, error) {
+		<-release
+		return order, nil
+	})
+	mockRepo.EXPECT().GetOrderByUID(ctx, "order-2").DoAndReturn(func(context.Context, string) (*models.Order, error) {
+		<-release
+		return nil, models.ErrOrderNotFound
+	})
+	mockCache.EXPECT().Set(gomock.Any(), order).Return(nil)
+
+	warmUp, err := service.RestoreCacheFromDB(ctx, interfaces.WarmUpOptions{Parallelism: 2, Background: true})
+	require.NoError(t, err)
+
+	progress := warmUp.Progress()
+	assert.Equal(t, 2, progress.Total)
+	assert.False(t, progress.Done)
+
+	close(release)
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+
+	progress = warmUp.Wait(waitCtx)
+	assert.True(t, progress.Done)
+	assert.Equal(t, 1, progress.Loaded)
+	assert.Equal(t, 1, progress.Failed)
+}
+
+func TestOrderService_RestoreCacheFromDBCancelled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockRepository(ctrl)
+
+	service := &OrderService{
+		orderRepo: mockRepo,
+		validator: &models.Validator{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	mockRepo.EXPECT().GetAllOrderUIDs(ctx).Return([]string{"order-1", "order-2", "order-3"}, nil)
+	mockRepo.EXPECT().GetOrderByUID(ctx, "order-1").DoAndReturn(func(context.Context, string) (*models.Order, error) {
+		cancel()
+		return nil, context.Canceled
+	})
+
+	warmUp, err := service.RestoreCacheFromDB(ctx, interfaces.WarmUpOptions{Parallelism: 1})
+	require.NoError(t, err)
+
+	progress := warmUp.Progress()
+	assert.True(t, progress.Done)
+	assert.Equal(t, 3, progress.Total)
+	assert.Equal(t, 1, progress.Failed)
+	assert.Equal(t, 0, progress.Loaded)
+}
+
+func withoutElapsed(progress interfaces.WarmUpProgress) interfaces.WarmUpProgress {
+	progress.Elapsed = 0
+	return progress
+}
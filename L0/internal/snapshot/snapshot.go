@@ -0,0 +1,129 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"L0/internal/models"
+)
+
+// File layout: magic, version (uint16), payload length (uint64) and the
+// CRC-32 of the payload (uint32), all big endian, followed by the
+// gob-encoded payload.
+const (
+	formatVersion uint16 = 1
+	headerSize           = 4 + 2 + 8 + 4
+)
+
+var magic = [4]byte{'L', '0', 'S', 'N'}
+
+var (
+	ErrCorrupt            = errors.New("snapshot is corrupt")
+	ErrUnsupportedVersion = errors.New("unsupported snapshot version")
+)
+
+// Snapshot is a copy of the cached orders together with the state of the
+// database at the time it was taken.
+type Snapshot struct {
+	CreatedAt time.Time
+	Summary   models.OrdersSummary
+	Orders    []*models.Order
+}
+
+func Write(w io.Writer, s *Snapshot) error {
+	var payload bytes.Buffer
+	if err := gob.NewEncoder(&payload).Encode(s); err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, magic[:])
+	binary.BigEndian.PutUint16(header[4:], formatVersion)
+	binary.BigEndian.PutUint64(header[6:], uint64(payload.Len()))
+	binary.BigEndian.PutUint32(header[14:], crc32.ChecksumIEEE(payload.Bytes()))
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write snapshot header: %w", err)
+	}
+	if _, err := w.Write(payload.Bytes()); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+func Read(r io.Reader) (*Snapshot, error) {
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot header: %v: %w", err, ErrCorrupt)
+	}
+
+	if !bytes.Equal(header[:4], magic[:]) {
+		return nil, fmt.Errorf("bad magic %q: %w", header[:4], ErrCorrupt)
+	}
+	if version := binary.BigEndian.Uint16(header[4:]); version != formatVersion {
+		return nil, fmt.Errorf("version %d: %w", version, ErrUnsupportedVersion)
+	}
+	length := binary.BigEndian.Uint64(header[6:])
+	checksum := binary.BigEndian.Uint32(header[14:])
+
+	// CopyN grows the buffer as data arrives, so a corrupt length cannot
+	// force a huge allocation up front.
+	var payload bytes.Buffer
+	if _, err := io.CopyN(&payload, r, int64(length)); err != nil {
+		return nil, fmt.Errorf("failed to read snapshot payload: %v: %w", err, ErrCorrupt)
+	}
+	if crc32.ChecksumIEEE(payload.Bytes()) != checksum {
+		return nil, fmt.Errorf("checksum mismatch: %w", ErrCorrupt)
+	}
+
+	var s Snapshot
+	if err := gob.NewDecoder(&payload).Decode(&s); err != nil {
+		return nil, fmt.Errorf("failed to decode snapshot: %v: %w", err, ErrCorrupt)
+	}
+	return &s, nil
+}
+
+// Save writes s to path atomically: readers see either the previous file or
+// the complete new one.
+func Save(path string, s *Snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create snapshot file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := Write(tmp, s); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to sync snapshot file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close snapshot file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace snapshot file: %w", err)
+	}
+	return nil
+}
+
+func Load(path string) (*Snapshot, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open snapshot: %w", err)
+	}
+	defer file.Close()
+
+	return Read(file)
+}
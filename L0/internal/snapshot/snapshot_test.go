@@ -0,0 +1,99 @@
+package snapshot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"L0/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSnapshot() *Snapshot {
+	created := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	return &Snapshot{
+		CreatedAt: created,
+		Summary:   models.OrdersSummary{Count: 2, LatestDateCreated: created},
+		Orders: []*models.Order{
+			{OrderUID: "order-1", DateCreated: created, Items: []models.Item{{Name: "item", Price: 100}}},
+			{OrderUID: "order-2", DateCreated: created.Add(-time.Hour)},
+		},
+	}
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testSnapshot()))
+
+	s, err := Read(&buf)
+	require.NoError(t, err)
+
+	want := testSnapshot()
+	assert.True(t, want.CreatedAt.Equal(s.CreatedAt))
+	assert.True(t, want.Summary.Equal(s.Summary))
+	require.Len(t, s.Orders, 2)
+	assert.Equal(t, "order-1", s.Orders[0].OrderUID)
+	assert.Equal(t, 100, s.Orders[0].Items[0].Price)
+}
+
+func TestRead_DetectsCorruption(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testSnapshot()))
+	valid := buf.Bytes()
+
+	flipped := bytes.Clone(valid)
+	flipped[len(flipped)-1] ^= 0xff
+
+	badMagic := bytes.Clone(valid)
+	badMagic[0] = 'X'
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: nil},
+		{name: "truncated header", data: valid[:headerSize-1]},
+		{name: "truncated payload", data: valid[:len(valid)-1]},
+		{name: "flipped payload byte", data: flipped},
+		{name: "bad magic", data: badMagic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(bytes.NewReader(tt.data))
+			assert.ErrorIs(t, err, ErrCorrupt)
+		})
+	}
+}
+
+func TestRead_RejectsUnknownVersion(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, Write(&buf, testSnapshot()))
+	data := buf.Bytes()
+	binary.BigEndian.PutUint16(data[4:], formatVersion+1)
+
+	_, err := Read(bytes.NewReader(data))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestSaveLoad(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "cache.snapshot")
+
+	_, err := Load(path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	require.NoError(t, Save(path, testSnapshot()))
+	s, err := Load(path)
+	require.NoError(t, err)
+	assert.Len(t, s.Orders, 2)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file must not be left behind")
+}
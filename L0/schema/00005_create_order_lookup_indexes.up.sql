@@ -0,0 +1,7 @@
+-- +goose Up
+CREATE INDEX IF NOT EXISTS idx_orders_track_number ON orders (track_number);
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders (customer_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_orders_customer_id;
+DROP INDEX IF EXISTS idx_orders_track_number;
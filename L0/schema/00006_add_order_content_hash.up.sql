@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64) NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS content_hash;
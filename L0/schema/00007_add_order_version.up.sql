@@ -0,0 +1,8 @@
+-- +goose Up
+-- Existing orders predate corrections and become version 1.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1
+    CONSTRAINT orders_version_positive CHECK (version > 0);
+
+-- +goose Down
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_version_positive;
+ALTER TABLE orders DROP COLUMN IF EXISTS version;
@@ -0,0 +1,35 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'created'
+    CONSTRAINT orders_status_known
+    CHECK (status IN ('created', 'paid', 'assembling', 'shipped', 'delivered', 'cancelled', 'returned'));
+
+CREATE TABLE order_status_history (
+    id         BIGSERIAL PRIMARY KEY,
+    order_uid  VARCHAR(100) NOT NULL REFERENCES orders(order_uid),
+    status     VARCHAR(20) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    source     VARCHAR(100) NOT NULL
+);
+
+CREATE INDEX idx_order_status_history_order_uid ON order_status_history (order_uid, id);
+
+-- Existing orders start their history as created.
+INSERT INTO order_status_history (order_uid, status, changed_at, source)
+SELECT order_uid, 'created', date_created, 'migration' FROM orders;
+
+-- +goose StatementBegin
+CREATE FUNCTION reject_status_history_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'order_status_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER order_status_history_append_only
+    BEFORE UPDATE OR DELETE ON order_status_history
+    FOR EACH ROW EXECUTE FUNCTION reject_status_history_change();
+
+-- +goose Down
+DROP TABLE IF EXISTS order_status_history;
+DROP FUNCTION IF EXISTS reject_status_history_change();
+ALTER TABLE orders DROP COLUMN IF EXISTS status;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Every insert or update of an order takes a new revision, so a cache
+-- snapshot can tell that orders changed in place.
+CREATE SEQUENCE order_revision_seq;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT nextval('order_revision_seq');
+
+-- +goose StatementBegin
+CREATE FUNCTION bump_order_revision() RETURNS trigger AS $$
+BEGIN
+    NEW.revision := nextval('order_revision_seq');
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER orders_bump_revision
+    BEFORE UPDATE ON orders
+    FOR EACH ROW EXECUTE FUNCTION bump_order_revision();
+
+-- +goose Down
+DROP TRIGGER IF EXISTS orders_bump_revision ON orders;
+DROP FUNCTION IF EXISTS bump_order_revision();
+ALTER TABLE orders DROP COLUMN IF EXISTS revision;
+DROP SEQUENCE IF EXISTS order_revision_seq;